@@ -0,0 +1,238 @@
+package logkit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// overflowMode is a kind of the async queue overflow behavior.
+type overflowMode int
+
+const (
+	overflowBlock overflowMode = iota
+	overflowDropNewest
+	overflowDropOldest
+	overflowDropBelow
+)
+
+// OverflowPolicy defines the behavior of the async logger when its queue is full.
+type OverflowPolicy struct {
+	mode  overflowMode
+	level slog.Level
+}
+
+var (
+	// OverflowBlock blocks the logging call until there is space in the queue. No records are lost.
+	OverflowBlock = OverflowPolicy{mode: overflowBlock}
+	// OverflowDropNewest drops the record being logged.
+	OverflowDropNewest = OverflowPolicy{mode: overflowDropNewest}
+	// OverflowDropOldest drops the oldest queued record to make space for the new one.
+	OverflowDropOldest = OverflowPolicy{mode: overflowDropOldest}
+)
+
+// OverflowDropBelow drops the record being logged if its level is below the given one.
+// Logging calls with records of the given level or above are blocked until there is space in the queue.
+func OverflowDropBelow(level slog.Level) OverflowPolicy {
+	return OverflowPolicy{mode: overflowDropBelow, level: level}
+}
+
+// asyncConfig defines a configuration of the async mode.
+type asyncConfig struct {
+	queueSize int
+	policy    OverflowPolicy
+}
+
+// WithAsync enables the async mode: records are put to a bounded queue and written by a background goroutine,
+// so slow writers do not block logging calls. policy defines the behavior when the queue is full.
+//
+// Queued records are written on Logger.Flush and Logger.Close, the latter should be called on shutdown
+// to avoid losing records. The amount of dropped records is available via Logger.DroppedRecords.
+func WithAsync(queueSize int, policy OverflowPolicy) Option {
+	return func(c *Config) error {
+		if queueSize <= 0 {
+			return errors.New("async queue size must be positive")
+		}
+		switch policy.mode {
+		case overflowBlock, overflowDropNewest, overflowDropOldest, overflowDropBelow:
+		default:
+			return errors.New("unknown async overflow policy")
+		}
+
+		c.async = &asyncConfig{queueSize: queueSize, policy: policy}
+		return nil
+	}
+}
+
+// asyncRecord is a record waiting in the queue along with the handler to write it.
+type asyncRecord struct {
+	ctx     context.Context
+	handler slog.Handler
+	record  slog.Record
+}
+
+// asyncQueue is a bounded queue of records processed by a single background goroutine.
+type asyncQueue struct {
+	policy  OverflowPolicy
+	dropped atomic.Uint64
+
+	mu     sync.Mutex
+	cond   *sync.Cond // Signals any change of the queue state.
+	buf    []asyncRecord
+	head   int
+	size   int
+	busy   bool // The worker is writing a record.
+	closed bool
+	done   chan struct{}
+}
+
+// newAsyncQueue returns a new queue and starts its worker.
+func newAsyncQueue(cfg *asyncConfig) *asyncQueue {
+	q := &asyncQueue{
+		policy: cfg.policy,
+		buf:    make([]asyncRecord, cfg.queueSize),
+		done:   make(chan struct{}),
+	}
+	q.cond = sync.NewCond(&q.mu)
+
+	go q.run()
+	return q
+}
+
+// enqueue puts the record to the queue according to the overflow policy.
+// After the queue is closed, records are written synchronously.
+func (q *asyncQueue) enqueue(ctx context.Context, h slog.Handler, r slog.Record) error {
+	q.mu.Lock()
+	for q.size == len(q.buf) && !q.closed {
+		switch q.policy.mode {
+		case overflowDropNewest:
+			q.mu.Unlock()
+			q.dropped.Add(1)
+			return nil
+		case overflowDropOldest:
+			q.pop()
+			q.dropped.Add(1)
+		case overflowDropBelow:
+			if r.Level < q.policy.level {
+				q.mu.Unlock()
+				q.dropped.Add(1)
+				return nil
+			}
+			q.cond.Wait()
+		default:
+			q.cond.Wait()
+		}
+	}
+
+	if q.closed {
+		q.mu.Unlock()
+		return h.Handle(ctx, r)
+	}
+
+	// Records are written after the logging call returns, so the context must not be cancelled by then.
+	q.buf[(q.head+q.size)%len(q.buf)] = asyncRecord{context.WithoutCancel(ctx), h, r}
+	q.size++
+	q.cond.Broadcast()
+	q.mu.Unlock()
+	return nil
+}
+
+// pop removes the oldest record from the queue. The caller must hold q.mu.
+func (q *asyncQueue) pop() asyncRecord {
+	item := q.buf[q.head]
+	q.buf[q.head] = asyncRecord{}
+	q.head = (q.head + 1) % len(q.buf)
+	q.size--
+	return item
+}
+
+// run writes queued records until the queue is closed and drained.
+func (q *asyncQueue) run() {
+	defer close(q.done)
+	for {
+		q.mu.Lock()
+		for q.size == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if q.size == 0 {
+			q.mu.Unlock()
+			return
+		}
+		item := q.pop()
+		q.busy = true
+		q.cond.Broadcast()
+		q.mu.Unlock()
+
+		// Errors are ignored: there is no place to report them.
+		_ = item.handler.Handle(item.ctx, item.record)
+
+		q.mu.Lock()
+		q.busy = false
+		q.cond.Broadcast()
+		q.mu.Unlock()
+	}
+}
+
+// flush waits until all queued records are written or ctx is done.
+func (q *asyncQueue) flush(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.cond.Broadcast()
+	})
+	defer stop()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.size > 0 || q.busy {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		q.cond.Wait()
+	}
+	return nil
+}
+
+// close stops accepting new records and waits until the queued ones are written or ctx is done.
+func (q *asyncQueue) close(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	select {
+	case <-q.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// asyncHandler is a slog.Handler which passes records to the next handler via the async queue.
+type asyncHandler struct {
+	queue *asyncQueue
+	next  slog.Handler
+}
+
+// Enabled implements slog.Handler.
+func (h *asyncHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *asyncHandler) Handle(ctx context.Context, r slog.Record) error {
+	// The record is cloned, as it is used after Handle returns.
+	return h.queue.enqueue(ctx, h.next, r.Clone())
+}
+
+// WithAttrs implements slog.Handler.
+func (h *asyncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &asyncHandler{h.queue, h.next.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+func (h *asyncHandler) WithGroup(name string) slog.Handler {
+	return &asyncHandler{h.queue, h.next.WithGroup(name)}
+}
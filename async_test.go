@@ -0,0 +1,115 @@
+package logkit_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	logger "github.com/Averlex/logkit"
+)
+
+// gatedWriter is a log entries collector which blocks writes until the gate is opened.
+type gatedWriter struct {
+	*customWriter
+	gate chan struct{}
+}
+
+func (w *gatedWriter) Write(data []byte) (int, error) {
+	<-w.gate
+	return w.customWriter.Write(data)
+}
+
+func (s *LoggerTestSuite) TestAsync() {
+	newLogger := func(w *gatedWriter, policy logger.OverflowPolicy) *logger.Logger {
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "trace"}),
+			logger.WithWriter(w),
+			logger.WithAsync(2, policy),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+		return l
+	}
+
+	messages := func(w *gatedWriter) []string {
+		res := make([]string, 0, len(w.arr))
+		for _, data := range w.arr {
+			entry, err := decodeJSON(data)
+			s.Require().NoError(err, "failed to unmarshal log entry")
+			res = append(res, entry.Msg)
+		}
+		return res
+	}
+
+	testCases := []struct {
+		name             string
+		policy           logger.OverflowPolicy
+		expectedMessages []string
+		expectedDropped  uint64
+	}{
+		// The first record is taken by the worker and blocked on write, the next two are queued.
+		{"drop newest", logger.OverflowDropNewest, []string{"0", "1", "2"}, 3},
+		{"drop oldest", logger.OverflowDropOldest, []string{"0", "4", "5"}, 3},
+		{"drop below", logger.OverflowDropBelow(logger.LevelError), []string{"0", "1", "2"}, 3},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			w := &gatedWriter{newCustomWriter(), make(chan struct{})}
+			l := newLogger(w, tC.policy)
+
+			l.Info(context.Background(), "0")
+			// Letting the worker take the first record.
+			time.Sleep(20 * time.Millisecond)
+			s.Require().ErrorIs(l.Flush(expiredContext()), context.Canceled, "expected pending records")
+			for i := 1; i < 6; i++ {
+				l.Info(context.Background(), fmt.Sprint(i))
+			}
+			close(w.gate)
+
+			s.Require().NoError(l.Flush(context.Background()), "got error, expected nil")
+			s.Require().Equal(tC.expectedMessages, messages(w), "unexpected messages written")
+			s.Require().Equal(tC.expectedDropped, l.DroppedRecords(), "unexpected amount of dropped records")
+			s.Require().NoError(l.Close(context.Background()), "got error, expected nil")
+		})
+	}
+
+	s.Run("block", func() {
+		w := &gatedWriter{newCustomWriter(), make(chan struct{})}
+		l := newLogger(w, logger.OverflowBlock)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := range 6 {
+				l.Info(context.Background(), fmt.Sprint(i))
+			}
+		}()
+
+		select {
+		case <-done:
+			s.FailNow("logging was not blocked")
+		case <-time.After(50 * time.Millisecond):
+		}
+		close(w.gate)
+		<-done
+
+		s.Require().NoError(l.Close(context.Background()), "got error, expected nil")
+		s.Require().Equal([]string{"0", "1", "2", "3", "4", "5"}, messages(w), "unexpected messages written")
+		s.Require().Zero(l.DroppedRecords(), "unexpected amount of dropped records")
+
+		l.Info(context.Background(), "after close")
+		s.Require().Len(w.arr, 7, "record after close was not written")
+	})
+
+	s.Run("invalid options", func() {
+		_, err := logger.NewLogger(logger.WithAsync(0, logger.OverflowBlock))
+		s.Require().Error(err, "got nil, expected error")
+	})
+}
+
+// expiredContext returns an already cancelled context.
+func expiredContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
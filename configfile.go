@@ -0,0 +1,203 @@
+package logkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WithConfigFile applies configuration from a JSON (".json") or YAML (".yaml", ".yml") file.
+// The file is expected to contain an object with the fields described in WithConfig, e.g.:
+//
+//	format: logfmt
+//	level: debug
+//	levels:
+//	  db: trace
+//
+// In strict mode unknown fields are reported as errors, otherwise they are ignored, as WithConfig does.
+// Validation errors are reported along with positions of the fields in the file, e.g.
+// "config data is invalid: invalid_value=level (logkit.yaml:2)".
+func WithConfigFile(path string, strict bool) Option {
+	return func(c *Config) error {
+		cfg, positions, err := readConfigFile(path)
+		if err != nil {
+			return err
+		}
+
+		ve := &ConfigError{positions: positions}
+		if strict {
+			for _, field := range unknownFields(cfg) {
+				ve.addUnknownKey(field, cfg[field])
+			}
+		}
+		return c.applyConfig(cfg, ve)
+	}
+}
+
+// readConfigFile decodes the config file. Positions of the fields are returned in "path:line" form,
+// nested fields are named with dots, e.g. "levels.db".
+func readConfigFile(path string) (map[string]any, map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("config file reading failed: %w", err)
+	}
+
+	lines := make(map[string]int)
+	var cfg map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		cfg, err = decodeJSONConfig(data, lines)
+	case ".yaml", ".yml":
+		cfg, err = decodeYAMLConfig(data, lines)
+	default:
+		return nil, nil, fmt.Errorf("unsupported config file extension %q, expected .json, .yaml or .yml", ext)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("config file %s decoding failed: %w", path, err)
+	}
+
+	positions := make(map[string]string, len(lines))
+	for field, line := range lines {
+		positions[field] = path + ":" + strconv.Itoa(line)
+	}
+	return cfg, positions, nil
+}
+
+// unknownFields returns sorted top-level fields of the config which are not supported.
+func unknownFields(cfg map[string]any) []string {
+	var res []string
+	for field := range cfg {
+		if _, ok := configFields[field]; !ok {
+			res = append(res, field)
+		}
+	}
+	slices.Sort(res)
+	return res
+}
+
+// errNotObject is returned if the config file does not contain an object at the top level.
+var errNotObject = errors.New("expected an object at the top level")
+
+// decodeJSONConfig decodes JSON object, saving lines of its fields.
+func decodeJSONConfig(data []byte, lines map[string]int) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	v, err := decodeJSONValue(dec, data, "", lines)
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return nil, fmt.Errorf("line %d: %w", lineAt(data, syntaxErr.Offset), err)
+		}
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("line %d: unexpected data after the top-level object", lineAt(data, dec.InputOffset()))
+	}
+
+	cfg, ok := v.(map[string]any)
+	if !ok {
+		return nil, errNotObject
+	}
+	return cfg, nil
+}
+
+// decodeJSONValue decodes the next JSON value. Lines of object fields are saved with the path prefix.
+func decodeJSONValue(dec *json.Decoder, data []byte, path string, lines map[string]int) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		obj := make(map[string]any)
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyTok.(string) // Object keys are always strings.
+			field := joinField(path, key)
+			lines[field] = lineAt(data, dec.InputOffset())
+
+			if obj[key], err = decodeJSONValue(dec, data, field, lines); err != nil {
+				return nil, err
+			}
+		}
+		_, err = dec.Token() // Closing delimiter.
+		return obj, err
+	case json.Delim('['):
+		arr := make([]any, 0)
+		for dec.More() {
+			v, err := decodeJSONValue(dec, data, path, lines)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		_, err = dec.Token() // Closing delimiter.
+		return arr, err
+	default:
+		return tok, nil
+	}
+}
+
+// decodeYAMLConfig decodes YAML mapping, saving lines of its fields.
+func decodeYAMLConfig(data []byte, lines map[string]int) (map[string]any, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return map[string]any{}, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, errNotObject
+	}
+	saveYAMLLines(root, "", lines)
+
+	var cfg map[string]any
+	if err := root.Decode(&cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// saveYAMLLines saves lines of the mapping fields with the path prefix.
+func saveYAMLLines(node *yaml.Node, path string, lines map[string]int) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		field := joinField(path, key.Value)
+		lines[field] = key.Line
+		if value.Kind == yaml.MappingNode {
+			saveYAMLLines(value, field, lines)
+		}
+	}
+}
+
+// joinField joins the field name with the path of its parent.
+func joinField(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// lineAt returns 1-based number of the line containing the offset.
+func lineAt(data []byte, offset int64) int {
+	offset = min(max(offset, 0), int64(len(data)))
+	return bytes.Count(data[:offset], []byte{'\n'}) + 1
+}
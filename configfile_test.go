@@ -0,0 +1,139 @@
+package logkit_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	logger "github.com/Averlex/logkit"
+)
+
+// writeConfigFile writes the config file to a temporary directory and returns its path.
+func (s *LoggerTestSuite) writeConfigFile(name, content string) string {
+	path := filepath.Join(s.T().TempDir(), name)
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o600), "failed to write config file")
+	return path
+}
+
+func (s *LoggerTestSuite) TestConfigFile() {
+	testCases := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "json",
+			file: "logkit.json",
+			content: `{
+	"format": "logfmt",
+	"level": "debug",
+	"max_backups": 3,
+	"levels": {"db": "trace"}
+}`,
+		},
+		{
+			name: "yaml",
+			file: "logkit.yaml",
+			content: `format: logfmt
+level: debug
+max_backups: 3
+levels:
+  db: trace
+`,
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			s.writer.CleanUp()
+			path := s.writeConfigFile(tC.file, tC.content)
+
+			l, err := logger.NewLogger(logger.WithConfigFile(path, true), logger.WithWriter(s.writer))
+			s.Require().NoError(err, "got error, expected nil")
+
+			ctx := context.Background()
+			l.Debug(ctx, "debug")
+			l.Named("db").Trace(ctx, "trace")
+			s.Require().Len(s.writer.arr, 2, "unexpected amount of logs received")
+			s.Require().Contains(string(s.writer.arr[0]), "level=DEBUG msg=debug", "unexpected log entry")
+			s.Require().Contains(string(s.writer.arr[1]), "level=TRACE msg=trace", "unexpected log entry")
+		})
+	}
+}
+
+func (s *LoggerTestSuite) TestConfigFileErrors() {
+	testCases := []struct {
+		name     string
+		file     string
+		content  string
+		strict   bool
+		expected []string
+	}{
+		{
+			name:     "json invalid values with positions",
+			file:     "logkit.json",
+			content:  "{\n\t\"format\": \"xml\",\n\t\"levels\": {\n\t\t\"db\": \"loud\"\n\t}\n}",
+			expected: []string{"format (", "logkit.json:2)", "levels.db (", "logkit.json:4)"},
+		},
+		{
+			name:     "yaml invalid type with position",
+			file:     "logkit.yml",
+			content:  "level: info\nadd_source: maybe\n",
+			expected: []string{"invalid_type=add_source (", "logkit.yml:2)"},
+		},
+		{
+			name:     "strict unknown key",
+			file:     "logkit.yaml",
+			content:  "level: info\nlevl: debug\n",
+			strict:   true,
+			expected: []string{"unknown_key=levl (", "logkit.yaml:2)"},
+		},
+		{
+			name:     "json syntax error",
+			file:     "logkit.json",
+			content:  "{\n\t\"level\": \"info\",\n\t\"format\" \"json\"\n}",
+			expected: []string{"line 3"},
+		},
+		{
+			name:     "yaml syntax error",
+			file:     "logkit.yaml",
+			content:  "level: info\nformat: [json\n",
+			expected: []string{"line"},
+		},
+		{
+			name:     "not an object",
+			file:     "logkit.json",
+			content:  `["level", "info"]`,
+			expected: []string{"expected an object"},
+		},
+		{
+			name:     "unsupported extension",
+			file:     "logkit.ini",
+			content:  "level=info",
+			expected: []string{"unsupported config file extension"},
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			path := s.writeConfigFile(tC.file, tC.content)
+
+			_, err := logger.NewLogger(logger.WithConfigFile(path, tC.strict))
+			s.Require().Error(err, "got nil, expected error")
+			for _, e := range tC.expected {
+				s.Require().Contains(err.Error(), e, "unexpected error message")
+			}
+		})
+	}
+
+	s.Run("unknown key is ignored in non-strict mode", func() {
+		path := s.writeConfigFile("logkit.yaml", "level: info\nlevl: debug\n")
+		_, err := logger.NewLogger(logger.WithConfigFile(path, false), logger.WithWriter(s.writer))
+		s.Require().NoError(err, "got error, expected nil")
+	})
+
+	s.Run("missing file", func() {
+		_, err := logger.NewLogger(logger.WithConfigFile(filepath.Join(s.T().TempDir(), "none.yaml"), false))
+		s.Require().ErrorIs(err, os.ErrNotExist, "unexpected error")
+	})
+}
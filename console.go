@@ -0,0 +1,260 @@
+package logkit
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ANSI escape sequences used by the console handler.
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiDim     = "\x1b[2m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+	ansiGray    = "\x1b[90m"
+)
+
+// levelColors maps log levels to colors of their badges.
+var levelColors = map[slog.Level]string{
+	LevelTrace:   ansiGray,
+	LevelDebug:   ansiCyan,
+	LevelVerbose: ansiBlue,
+	LevelInfo:    ansiGreen,
+	LevelWarn:    ansiYellow,
+	LevelError:   ansiRed,
+	LevelFatal:   ansiBold + ansiMagenta,
+}
+
+const (
+	// consoleLevelWidth is a width of the level badge, equal to the longest level name.
+	consoleLevelWidth = len("VERBOSE")
+	// consoleMsgWidth is a width the message is padded to, so attributes of subsequent records are aligned.
+	consoleMsgWidth = 40
+	// consoleIndent is an indentation of multi-line attribute values.
+	consoleIndent = "    "
+)
+
+// consoleAttr is a flattened attribute of the console handler.
+type consoleAttr struct {
+	key   string
+	value slog.Value
+}
+
+// consoleHandler is a slog.Handler which writes human-friendly records for local development:
+//
+//	10:00:00.000 INFO    Request handled                          method=GET status=200
+//
+// Errors and multi-line values are written below the record line as indented blocks.
+// Levels are colored, timestamps are dimmed, unless colors are disabled.
+type consoleHandler struct {
+	opts   slog.HandlerOptions
+	mu     *sync.Mutex
+	w      io.Writer
+	color  bool
+	attrs  []consoleAttr // Attributes added via WithAttrs.
+	prefix string        // Dotted prefix of the open groups.
+	groups []string      // Open groups, passed to ReplaceAttr.
+}
+
+// newConsoleHandler returns a new console handler writing to w.
+// Colors are enabled only if w is a terminal and NO_COLOR environment variable is not set.
+func newConsoleHandler(w io.Writer, opts *slog.HandlerOptions) *consoleHandler {
+	h := &consoleHandler{mu: &sync.Mutex{}, w: w, color: colorEnabled(w)}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+// colorEnabled returns true if colored output is supported by w.
+// See https://no-color.org for NO_COLOR environment variable convention.
+func colorEnabled(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Enabled implements slog.Handler.
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+// Handle implements slog.Handler.
+func (h *consoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var (
+		line   []byte
+		blocks []consoleAttr
+	)
+	// Built-in attributes might be changed or removed by ReplaceAttr, the same way as in slog handlers.
+	builtin := func(a slog.Attr) (slog.Value, bool) {
+		var res slog.Value
+		found := false
+		flattenAttr(h.opts.ReplaceAttr, "", nil, a, func(_ string, v slog.Value) {
+			res, found = v, true
+		})
+		return res, found
+	}
+
+	if !r.Time.IsZero() {
+		if v, ok := builtin(slog.Time(slog.TimeKey, r.Time)); ok {
+			line = h.appendColored(line, ansiDim, consoleString(v))
+			line = append(line, ' ')
+		}
+	}
+	if v, ok := builtin(slog.Any(slog.LevelKey, r.Level)); ok {
+		level := consoleString(v)
+		line = h.appendColored(line, levelColors[r.Level], level)
+		line = append(line, strings.Repeat(" ", max(consoleLevelWidth-len(level), 0)+1)...)
+	}
+	if h.opts.AddSource && r.PC != 0 {
+		if v, ok := builtin(slog.Any(slog.SourceKey, recordSource(r))); ok {
+			line = h.appendColored(line, ansiDim, consoleString(v))
+			line = append(line, ' ')
+		}
+	}
+	msg := r.Message
+	if v, ok := builtin(slog.String(slog.MessageKey, r.Message)); ok {
+		msg = consoleString(v)
+	}
+	line = append(line, msg...)
+
+	attrs := slices.Clip(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = h.appendAttr(attrs, h.prefix, h.groups, a)
+		return true
+	})
+
+	// Messages are padded to align the inline attributes, blocks are written on the next lines.
+	if slices.ContainsFunc(attrs, func(a consoleAttr) bool { return !isBlockValue(a.value) }) {
+		line = append(line, strings.Repeat(" ", max(consoleMsgWidth-utf8.RuneCountInString(msg), 0))...)
+	}
+	for _, a := range attrs {
+		if isBlockValue(a.value) {
+			blocks = append(blocks, a)
+			continue
+		}
+		line = append(line, ' ')
+		line = h.appendColored(line, ansiDim, a.key+"=")
+		line = appendLogfmtValue(line, a.value)
+	}
+	line = append(line, '\n')
+
+	for _, a := range blocks {
+		line = h.appendBlock(line, a)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(line)
+	return err
+}
+
+// WithAttrs implements slog.Handler.
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.attrs = slices.Clip(h.attrs)
+	for _, a := range attrs {
+		h2.attrs = h.appendAttr(h2.attrs, h.prefix, h.groups, a)
+	}
+	return &h2
+}
+
+// WithGroup implements slog.Handler.
+func (h *consoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	h2.groups = append(slices.Clip(h.groups), name)
+	return &h2
+}
+
+// appendAttr appends the flattened attribute to attrs.
+// Errors are kept unresolved, so they are written as blocks with the whole chain.
+func (h *consoleHandler) appendAttr(attrs []consoleAttr, prefix string, groups []string, a slog.Attr) []consoleAttr {
+	if ev, ok := a.Value.Any().(errorValue); ok && a.Value.Kind() == slog.KindLogValuer {
+		return append(attrs, consoleAttr{prefix + a.Key, slog.AnyValue(ev)})
+	}
+	flattenAttr(h.opts.ReplaceAttr, prefix, groups, a, func(key string, v slog.Value) {
+		attrs = append(attrs, consoleAttr{key, v})
+	})
+	return attrs
+}
+
+// appendBlock appends the attribute as an indented block:
+//
+//	key:
+//	    line 1
+//	    line 2
+func (h *consoleHandler) appendBlock(buf []byte, a consoleAttr) []byte {
+	color := ansiDim
+	if _, ok := a.value.Any().(error); ok {
+		color = ansiRed
+	}
+
+	buf = append(buf, consoleIndent...)
+	buf = h.appendColored(buf, color, a.key+":")
+	buf = append(buf, '\n')
+	for _, l := range strings.Split(strings.TrimRight(consoleString(a.value), "\n"), "\n") {
+		buf = append(buf, consoleIndent+consoleIndent...)
+		buf = append(buf, l...)
+		buf = append(buf, '\n')
+	}
+	return buf
+}
+
+// appendColored appends s wrapped into the color escape sequences if colors are enabled.
+func (h *consoleHandler) appendColored(buf []byte, color, s string) []byte {
+	if !h.color || color == "" {
+		return append(buf, s...)
+	}
+	buf = append(buf, color...)
+	buf = append(buf, s...)
+	return append(buf, ansiReset...)
+}
+
+// isBlockValue returns true if the value should be written as a separate block below the record line.
+func isBlockValue(v slog.Value) bool {
+	if _, ok := v.Any().(error); ok {
+		return true
+	}
+	return strings.Contains(consoleString(v), "\n")
+}
+
+// consoleString returns an unquoted string representation of the value.
+func consoleString(v slog.Value) string {
+	if v.Kind() == slog.KindAny || v.Kind() == slog.KindLogValuer {
+		return anyToString(v.Any())
+	}
+	return v.String()
+}
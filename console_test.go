@@ -0,0 +1,90 @@
+package logkit_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestConsole() {
+	l, err := logger.NewLogger(
+		logger.WithConfig(map[string]any{"format": "console", "level": "trace", "time_template": time.DateTime}),
+		logger.WithWriter(s.writer),
+	)
+	s.Require().NoError(err, "got error, expected nil")
+
+	testCases := []struct {
+		name     string
+		log      func()
+		expected []string
+	}{
+		{
+			name: "no attributes",
+			log:  func() { l.Info(context.Background(), "started") },
+			expected: []string{
+				"INFO    started",
+			},
+		},
+		{
+			name: "aligned attributes",
+			log: func() {
+				l.With("service", "auth").Verbose(context.Background(), "handled", slog.Group("req", "id", 1, "path", "/a b"))
+			},
+			expected: []string{
+				`VERBOSE handled                                  service=auth req.id=1 req.path="/a b"`,
+			},
+		},
+		{
+			name: "multi-line values",
+			log: func() {
+				l.Error(context.Background(), "failed", "error", errors.New("boom"), "stack", "main.main\n\tmain.go:10", "id", 1)
+			},
+			expected: []string{
+				"ERROR   failed                                   id=1",
+				"    error:",
+				"        boom (*errors.errorString)",
+				"    stack:",
+				"        main.main",
+				"        \tmain.go:10",
+			},
+		},
+		{
+			name: "blocks only",
+			log:  func() { l.Error(context.Background(), "failed", errors.New("boom")) },
+			expected: []string{
+				"ERROR   failed",
+				"    error:",
+				"        boom (*errors.errorString)",
+			},
+		},
+		{
+			name: "multi-byte message",
+			log:  func() { l.Info(context.Background(), "запуск", "id", 1) },
+			expected: []string{
+				"INFO    запуск                                   id=1",
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			s.writer.CleanUp()
+			tC.log()
+			s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+
+			data := string(s.writer.arr[0])
+			s.Require().NotContains(data, "\x1b[", "colors are expected to be disabled for non-terminal writers")
+			lines := strings.Split(strings.TrimSuffix(data, "\n"), "\n")
+			s.Require().Len(lines, len(tC.expected), "unexpected amount of lines")
+
+			_, err := time.Parse(time.DateTime, lines[0][:len(time.DateTime)])
+			s.Require().NoError(err, "unexpected time format")
+			lines[0] = lines[0][len(time.DateTime)+1:]
+			s.Require().Equal(tC.expected, lines, "unexpected record")
+		})
+	}
+}
@@ -3,17 +3,39 @@ package logkit
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
-	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
 )
 
 // Logger is a wrapper around slog.Logger that supports:
 //   - automatic context field injection.
 //   - custom log levels (TRACE, VERBOSE, FATAL).
 //   - configurable time format and output.
+//   - log level adjustable at runtime.
+//   - named child loggers with their own log levels.
 type Logger struct {
-	l              *slog.Logger
-	extraCtxFields []any // The field is read-only: writing is possible only on logger initialization.
+	l               *slog.Logger
+	extraCtxFields  []any          // The field is read-only: writing is possible only on logger initialization.
+	levels          *levelRegistry // Shared by all loggers derived via With and Named.
+	name            string         // Dotted component name, empty for the root logger.
+	closer          io.Closer      // Writer owned by the logger, may be nil.
+	async           *asyncQueue    // Queue of the async mode, may be nil.
+	fatal           fatalConfig
+	addSource       bool                      // Capture the caller's program counter for the source location.
+	callerSkip      int                       // Additional frames to skip when capturing the caller.
+	stacktraceLevel *slog.Level               // Minimal level of records with stack traces, nil if disabled.
+	settings        *atomic.Pointer[Settings] // Applied configuration, shared by all derived loggers.
+	trace           *traceKeys                // Keys of the trace context attributes, nil if disabled.
+	spanEventsLevel *slog.Level               // Minimal level of records added as span events, nil if disabled.
+	attrs           []slog.Attr               // Attributes added via With, kept for span events only.
+	sinks           []sink                    // Additional destinations of records, e.g. OTLP exporters.
+	sampler         *sampler                  // Sampler of records, may be nil.
+	dedup           *deduplicator             // Deduplicator of records, may be nil.
+	limiter         *rateLimiter              // Rate limiter of records, may be nil.
 }
 
 // addContextData extracts values from the context using keys defined via WithExtraContextFields.
@@ -29,7 +51,17 @@ type Logger struct {
 // Note: the following logic ensures compatibility with slog's requirement that attribute keys be strings,
 // while allowing context keys to be any comparable type (e.g. custom structs or named string types) as long as
 // they provide a string representation via fmt.Stringer or are plain strings themselves.
+//
+// For named loggers, the logger name is added under LoggerKey.
+// If WithTraceContext is enabled, the trace context of the span in ctx is added as well.
+// Attributes added to ctx via ContextWith are added last.
 func (logg Logger) addContextData(ctx context.Context, args ...any) []any {
+	if logg.name != "" {
+		args = append(args, slog.String(LoggerKey, logg.name))
+	}
+	if logg.trace != nil {
+		args = logg.trace.appendTraceContext(ctx, args)
+	}
 	for _, k := range logg.extraCtxFields {
 		v := ctx.Value(k)
 		if v == nil {
@@ -48,47 +80,215 @@ func (logg Logger) addContextData(ctx context.Context, args ...any) []any {
 			args = append(args, slog.Any(k.String(), v))
 		}
 	}
+	for _, a := range ctxAttrs(ctx) {
+		args = append(args, a)
+	}
 
 	return args
 }
 
+// log logs a message with the given level if it is enabled for the logger. Disabled records are passed
+// to the request buffer of ctx, if any, see ContextWithBuffer.
+// It must be called directly by the exported logging methods, so the caller is captured correctly.
+// Callers in between must be accounted for in callerSkip.
+func (logg Logger) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	buf := tailBufferFromContext(ctx)
+	if level >= LevelError {
+		buf.flush()
+	}
+
+	handler := logg.l.Handler()
+	enabled := level >= logg.levels.level(logg.name) && handler.Enabled(ctx, level)
+	if !enabled && !buf.accepts(level) {
+		return
+	}
+
+	var pc uintptr
+	if logg.addSource {
+		var pcs [1]uintptr
+		// Skipping runtime.Callers, log and the exported logging method.
+		runtime.Callers(3+logg.callerSkip, pcs[:])
+		pc = pcs[0]
+	}
+
+	r := slog.NewRecord(time.Now(), level, msg, pc)
+	r.Add(logg.addContextData(ctx, convertErrors(args)...)...)
+	// Records kept in the tail buffer are written only if the request fails, so the stack is not captured for them.
+	if enabled && logg.stacktraceLevel != nil && level >= *logg.stacktraceLevel {
+		// Skipping captureStack, log and the exported logging method.
+		r.AddAttrs(slog.Any(StackKey, captureStack(3+logg.callerSkip)))
+	}
+	if !enabled {
+		buf.add(ctx, handler, r)
+		return
+	}
+	if logg.spanEventsLevel != nil && level >= *logg.spanEventsLevel {
+		logg.addSpanEvent(ctx, r)
+	}
+	// Errors are ignored, as slog.Logger does.
+	_ = handler.Handle(ctx, r)
+}
+
 // Trace logs a message with level Trace on the standard logger.
 func (logg Logger) Trace(ctx context.Context, msg string, args ...any) {
-	logg.l.Log(ctx, LevelTrace, msg, logg.addContextData(ctx, args...)...)
+	logg.log(ctx, LevelTrace, msg, args...)
 }
 
 // Debug logs a message with level Debug on the standard logger.
 func (logg Logger) Debug(ctx context.Context, msg string, args ...any) {
-	logg.l.Log(ctx, LevelDebug, msg, logg.addContextData(ctx, args...)...)
+	logg.log(ctx, LevelDebug, msg, args...)
 }
 
 // Verbose logs a message with level Verbose on the standard logger.
 func (logg Logger) Verbose(ctx context.Context, msg string, args ...any) {
-	logg.l.Log(ctx, LevelVerbose, msg, logg.addContextData(ctx, args...)...)
+	logg.log(ctx, LevelVerbose, msg, args...)
 }
 
 // Info logs a message with level Info on the standard logger.
 func (logg Logger) Info(ctx context.Context, msg string, args ...any) {
-	logg.l.Log(ctx, LevelInfo, msg, logg.addContextData(ctx, args...)...)
+	logg.log(ctx, LevelInfo, msg, args...)
 }
 
 // Warn logs a message with level Warn on the standard logger.
 func (logg Logger) Warn(ctx context.Context, msg string, args ...any) {
-	logg.l.Log(ctx, LevelWarn, msg, logg.addContextData(ctx, args...)...)
+	logg.log(ctx, LevelWarn, msg, args...)
 }
 
 // Error logs a message with level Error on the standard logger.
 func (logg Logger) Error(ctx context.Context, msg string, args ...any) {
-	logg.l.Log(ctx, LevelError, msg, logg.addContextData(ctx, args...)...)
+	logg.log(ctx, LevelError, msg, args...)
 }
 
-// Fatal logs a message with level Error on the standard logger and then calls os.Exit(1).
+// Fatal logs a message with level Fatal on the standard logger and then exits with code 1.
+// Before the exit, it runs hooks registered via WithFatalHooks and closes the logger,
+// so queued records are written. The exit function might be replaced via WithExitFunc.
 func (logg Logger) Fatal(ctx context.Context, msg string, args ...any) {
-	logg.l.Log(ctx, LevelFatal, msg, logg.addContextData(ctx, args...)...)
-	os.Exit(1)
+	logg.log(ctx, LevelFatal, msg, args...)
+	logg.exit(ctx)
 }
 
 // With returns a new Logger that adds the given key-value pairs to the logger's context.
 func (logg Logger) With(args ...any) *Logger {
-	return &Logger{logg.l.With(args...), logg.extraCtxFields}
+	args = convertErrors(args)
+	logg.l = logg.l.With(args...)
+	if logg.spanEventsLevel != nil {
+		logg.attrs = withSpanAttrs(logg.attrs, args)
+	}
+	return &logg
+}
+
+// Named returns a new Logger for the component with the given name.
+// Names of nested components are joined with dots: logger.Named("http").Named("client") is named "http.client".
+// The name is added to every record under LoggerKey.
+//
+// The component level is resolved from the closest configured ancestor, e.g. for "http.client"
+// the "levels" config entries are checked for "http.client", then "http". If none is found,
+// the level of the root logger is used.
+func (logg Logger) Named(name string) *Logger {
+	switch {
+	case name == "":
+	case logg.name == "":
+		logg.name = name
+	default:
+		logg.name += "." + name
+	}
+	return &logg
+}
+
+// Level returns the name of the effective log level of the logger, e.g. "INFO".
+func (logg Logger) Level() string {
+	return levelName(logg.levels.level(logg.name))
+}
+
+// SetLevel changes the log level of the logger. Accepted values are the same as for the "level" config field:
+// "trace", "debug", "verbose", "info", "warn", "error", "fatal". The value is case-insensitive.
+//
+// For the root logger, the change affects all loggers derived from it via With,
+// as well as named loggers without their own level.
+// For a named logger, the change affects the component and its nested components without their own level.
+//
+// It is safe to call SetLevel concurrently with logging.
+func (logg Logger) SetLevel(level string) error {
+	lvl, ok := levelValues[strings.ToLower(level)]
+	if !ok {
+		return fmt.Errorf("unknown log level %q: %w", level, ErrInvalidLevel)
+	}
+	logg.levels.setLevel(logg.name, lvl)
+	return nil
+}
+
+// Flush waits until all records queued in the async mode are written and the records buffered by sinks,
+// e.g. the OTLP exporter, are exported. It returns ctx.Err() if ctx is done earlier.
+// Without the async mode and sinks, Flush does nothing.
+func (logg Logger) Flush(ctx context.Context) error {
+	if logg.async != nil {
+		if err := logg.async.flush(ctx); err != nil {
+			return err
+		}
+	}
+	for _, s := range logg.sinks {
+		if err := s.flush(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DroppedRecords returns the number of records dropped in the async mode due to the queue overflow.
+func (logg Logger) DroppedRecords() uint64 {
+	if logg.async == nil {
+		return 0
+	}
+	return logg.async.dropped.Load()
+}
+
+// Close stops the sampling, deduplication and rate limiting, writes the records queued in the async mode,
+// exports the records buffered by sinks and releases resources owned by the logger, e.g. the log file opened
+// for "file" log stream. Writers set via WithWriter are not closed.
+// The resources are shared by all loggers derived via With and Named, so Close should be called once,
+// after logging is finished. Records logged after Close are written synchronously.
+//
+// If ctx is done before the resources are released, Close returns ctx.Err().
+func (logg Logger) Close(ctx context.Context) error {
+	if logg.sampler != nil {
+		if err := logg.sampler.close(ctx); err != nil {
+			return err
+		}
+	}
+	if logg.dedup != nil {
+		if err := logg.dedup.close(ctx); err != nil {
+			return err
+		}
+	}
+	if logg.limiter != nil {
+		if err := logg.limiter.close(ctx); err != nil {
+			return err
+		}
+	}
+	if logg.async != nil {
+		if err := logg.async.close(ctx); err != nil {
+			return err
+		}
+	}
+	for _, s := range logg.sinks {
+		if err := s.close(ctx); err != nil {
+			return err
+		}
+	}
+
+	if logg.closer == nil {
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- logg.closer.Close()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -0,0 +1,55 @@
+package logkit
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// ctxAttrsKey is the context key of the attributes added via ContextWith.
+type ctxAttrsKey struct{}
+
+// ContextWith returns a copy of ctx carrying the given attributes along with the ones already added to ctx.
+// Arguments are handled as in Logger.With: key-value pairs, slog.Attr values and errors.
+//
+// The attributes are added to every record logged with the returned context or contexts derived from it,
+// no keys registration is required. Attributes with the same key are de-duplicated: the value added
+// by the innermost call wins and keeps the position of the outer one.
+//
+// Example:
+//
+//	ctx = logkit.ContextWith(ctx, "request_id", id, "user_id", 42)
+//	// In a deeper layer:
+//	ctx = logkit.ContextWith(ctx, "user_id", 43, slog.String("order_id", orderID))
+//	logger.Info(ctx, "order created") // request_id=..., user_id=43, order_id=...
+func ContextWith(ctx context.Context, args ...any) context.Context {
+	if len(args) == 0 {
+		return ctx
+	}
+
+	var r slog.Record
+	r.Add(convertErrors(args)...)
+
+	attrs := slices.Clone(ctxAttrs(ctx))
+	r.Attrs(func(a slog.Attr) bool {
+		i := slices.IndexFunc(attrs, func(b slog.Attr) bool { return b.Key == a.Key })
+		if i < 0 || a.Key == "" {
+			attrs = append(attrs, a)
+		} else {
+			attrs[i] = a
+		}
+		return true
+	})
+	return context.WithValue(ctx, ctxAttrsKey{}, attrs)
+}
+
+// AttrsFromContext returns a copy of the attributes added to ctx via ContextWith, nil if there are none.
+func AttrsFromContext(ctx context.Context) []slog.Attr {
+	return slices.Clone(ctxAttrs(ctx))
+}
+
+// ctxAttrs returns the attributes added to ctx via ContextWith. The slice is shared and must not be modified.
+func ctxAttrs(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(ctxAttrsKey{}).([]slog.Attr)
+	return attrs
+}
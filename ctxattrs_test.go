@@ -0,0 +1,49 @@
+package logkit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestContextWith() {
+	s.Run("records", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(logger.WithConfig(map[string]any{"level": "info"}), logger.WithWriter(s.writer))
+		s.Require().NoError(err, "got error, expected nil")
+
+		outer := logger.ContextWith(context.Background(), "request_id", "abc-123", "user_id", 42)
+		inner := logger.ContextWith(outer, slog.Int("user_id", 43), "order_id", "o-1")
+
+		l.Info(inner, "order created", "amount", 10)
+		l.Info(outer, "request handled")
+		s.Require().Len(s.writer.arr, 2, "unexpected amount of logs received")
+
+		s.Require().Contains(string(s.writer.arr[0]),
+			`"amount":10,"request_id":"abc-123","user_id":43,"order_id":"o-1"`, "unexpected attributes")
+
+		var entry map[string]any
+		s.Require().NoError(json.Unmarshal(s.writer.arr[1], &entry), "failed to unmarshal log entry")
+		s.Require().Equal(float64(42), entry["user_id"], "outer context must not be affected")
+		s.Require().NotContains(entry, "order_id", "outer context must not be affected")
+	})
+
+	s.Run("attrs", func() {
+		s.Require().Nil(logger.AttrsFromContext(context.Background()), "unexpected attributes")
+
+		ctx := logger.ContextWith(context.Background(), "a", 1, "b", 2, "a", 3, errors.New("boom"))
+		attrs := logger.AttrsFromContext(ctx)
+		s.Require().Len(attrs, 3, "unexpected amount of attributes")
+		s.Require().Equal("a", attrs[0].Key, "unexpected attribute")
+		s.Require().Equal(int64(3), attrs[0].Value.Int64(), "inner value must override outer one")
+		s.Require().Equal("b", attrs[1].Key, "unexpected attribute")
+		s.Require().Equal(logger.ErrorKey, attrs[2].Key, "errors must be converted")
+
+		attrs[0] = slog.Int("a", 0)
+		s.Require().Equal(int64(3), logger.AttrsFromContext(ctx)[0].Value.Int64(), "context attributes must be copied")
+		s.Require().Equal(ctx, logger.ContextWith(ctx), "context without attributes must be returned as is")
+	})
+}
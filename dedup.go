@@ -0,0 +1,198 @@
+package logkit
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dedupConfig defines a configuration of the deduplication.
+type dedupConfig struct {
+	window time.Duration
+	attrs  []string // Keys of the attributes identifying records along with the level and message.
+}
+
+// dedupAttrs parses the comma-separated list of attribute keys.
+func dedupAttrs(s string) []string {
+	var res []string
+	for _, key := range strings.Split(s, ",") {
+		if key = strings.TrimSpace(key); key != "" && !slices.Contains(res, key) {
+			res = append(res, key)
+		}
+	}
+	return res
+}
+
+// dedupEntry holds the state of identical records.
+type dedupEntry struct {
+	level slog.Level
+	msg   string
+	attrs []slog.Attr // Selected attributes of the records.
+	last  time.Time   // Time of the last record, suppressed or not.
+	// Repeats suppressed since the last summary.
+	count int
+	first time.Time
+}
+
+// summary returns the record summarizing the suppressed repeats.
+func (e *dedupEntry) summary() slog.Record {
+	r := slog.NewRecord(time.Now(), e.level, e.msg, 0)
+	r.AddAttrs(e.attrs...)
+	r.AddAttrs(
+		slog.Int(RepeatCountKey, e.count),
+		slog.Time(FirstSeenKey, e.first),
+		slog.Time(LastSeenKey, e.last),
+	)
+	return r
+}
+
+// deduplicator suppresses repeats of identical records and summarizes them.
+type deduplicator struct {
+	cfg     dedupConfig
+	out     slog.Handler // Handler of the summaries.
+	mu      sync.Mutex
+	entries map[string]*dedupEntry
+	closed  bool // Repeats are not suppressed after the deduplicator is stopped, as there is no one to summarize them.
+	rep     *reporter
+}
+
+// newDeduplicator returns a new deduplicator and starts its summary goroutine. Summaries are written to out.
+func newDeduplicator(cfg *dedupConfig, out slog.Handler) *deduplicator {
+	d := &deduplicator{cfg: *cfg, out: out, entries: make(map[string]*dedupEntry)}
+	// Records of different named loggers are never identical, and summaries keep the name.
+	d.cfg.attrs = append([]string{LoggerKey}, slices.DeleteFunc(slices.Clone(cfg.attrs), func(key string) bool {
+		return key == LoggerKey
+	})...)
+	d.rep = startReporter(cfg.window, d.summarize)
+	return d
+}
+
+// allow implements recordFilter.
+func (d *deduplicator) allow(r slog.Record, withAttrs []slog.Attr) bool {
+	attrs := d.selectAttrs(r, withAttrs)
+	key := dedupKey(r, attrs)
+
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return true
+	}
+	e, ok := d.entries[key]
+	if ok && r.Time.Sub(e.last) < d.cfg.window {
+		// Concurrent records might come out of order.
+		if e.count == 0 || r.Time.Before(e.first) {
+			e.first = r.Time
+		}
+		e.count++
+		e.last = later(e.last, r.Time)
+		d.mu.Unlock()
+		return false
+	}
+
+	// The window has passed: repeats which are not summarized yet are reported before the record.
+	var pending *dedupEntry
+	if ok && e.count > 0 {
+		pending = &dedupEntry{}
+		*pending = *e
+	}
+	d.entries[key] = &dedupEntry{level: r.Level, msg: r.Message, attrs: attrs, last: r.Time}
+	d.mu.Unlock()
+
+	if pending != nil {
+		d.write(pending.summary())
+	}
+	return true
+}
+
+// selectAttrs returns the configured attributes of the record and the logger name. Record attributes take
+// precedence over the logger ones. Attributes are matched by their keys, groups are not taken into account.
+func (d *deduplicator) selectAttrs(r slog.Record, withAttrs []slog.Attr) []slog.Attr {
+	found := make(map[string]slog.Attr, len(d.cfg.attrs))
+	for _, a := range withAttrs {
+		if slices.Contains(d.cfg.attrs, a.Key) {
+			found[a.Key] = a
+		}
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		if slices.Contains(d.cfg.attrs, a.Key) {
+			found[a.Key] = a
+		}
+		return true
+	})
+
+	attrs := make([]slog.Attr, 0, len(found))
+	for _, key := range d.cfg.attrs {
+		if a, ok := found[key]; ok {
+			attrs = append(attrs, a)
+		}
+	}
+	return attrs
+}
+
+// later returns the later of the times.
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// dedupKey returns the key identifying identical records.
+func dedupKey(r slog.Record, attrs []slog.Attr) string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(int(r.Level)))
+	b.WriteByte(0)
+	b.WriteString(r.Message)
+	for _, a := range attrs {
+		b.WriteByte(0)
+		b.WriteString(a.Key)
+		b.WriteByte('=')
+		b.WriteString(a.Value.Resolve().String())
+	}
+	return b.String()
+}
+
+// summarize writes summaries of the suppressed repeats, ordered by the first repeat.
+// Entries outside the window are removed; all entries are removed if all is true.
+func (d *deduplicator) summarize(all bool) {
+	now := time.Now()
+	var pending []*dedupEntry
+
+	d.mu.Lock()
+	for key, e := range d.entries {
+		if e.count > 0 {
+			summary := &dedupEntry{}
+			*summary = *e
+			pending = append(pending, summary)
+			e.count = 0
+		}
+		if all || now.Sub(e.last) >= d.cfg.window {
+			delete(d.entries, key)
+		}
+	}
+	d.mu.Unlock()
+
+	slices.SortFunc(pending, func(a, b *dedupEntry) int { return a.first.Compare(b.first) })
+	for _, e := range pending {
+		d.write(e.summary())
+	}
+}
+
+// write writes the summary record.
+func (d *deduplicator) write(r slog.Record) {
+	// Errors are ignored, as slog.Logger does.
+	_ = d.out.Handle(context.Background(), r)
+}
+
+// close stops the deduplicator, summarizing the suppressed repeats.
+func (d *deduplicator) close(ctx context.Context) error {
+	return d.rep.close(ctx, func() {
+		d.mu.Lock()
+		d.closed = true
+		d.mu.Unlock()
+	})
+}
@@ -0,0 +1,148 @@
+package logkit_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestDedup() {
+	ctx := context.Background()
+
+	decode := func(data []byte) map[string]any {
+		var entry map[string]any
+		s.Require().NoError(json.Unmarshal(data, &entry), "failed to unmarshal log entry")
+		return entry
+	}
+
+	s.Run("summaries", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info", "dedup_window": "1h", "dedup_attrs": "tenant_id, shard"}),
+			logger.WithWriter(s.writer),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		for range 5 {
+			l.With("tenant_id", "a").Error(ctx, "db down", "attempt", 1)
+		}
+		for range 2 {
+			l.Error(ctx, "db down", "tenant_id", "b")
+		}
+		l.Info(ctx, "other")
+		s.Require().Len(s.writer.arr, 3, "repeats must be suppressed")
+
+		s.writer.CleanUp()
+		s.Require().NoError(l.Close(ctx), "got error, expected nil")
+		s.Require().Len(s.writer.arr, 2, "unexpected amount of summaries")
+
+		summary := decode(s.writer.arr[0])
+		s.Require().Equal("ERROR", summary["level"], "unexpected summary level")
+		s.Require().Equal("db down", summary["msg"], "unexpected summary message")
+		s.Require().Equal("a", summary["tenant_id"], "selected attributes must be added")
+		s.Require().NotContains(summary, "attempt", "only selected attributes must be added")
+		s.Require().Equal(float64(4), summary[logger.RepeatCountKey], "unexpected repeat count")
+		s.Require().Contains(summary, logger.FirstSeenKey, "first seen time must be added")
+		s.Require().Contains(summary, logger.LastSeenKey, "last seen time must be added")
+
+		first, err := time.Parse(logger.DefaultTimeTemplate, summary[logger.FirstSeenKey].(string))
+		s.Require().NoError(err, "failed to parse first seen time")
+		last, err := time.Parse(logger.DefaultTimeTemplate, summary[logger.LastSeenKey].(string))
+		s.Require().NoError(err, "failed to parse last seen time")
+		s.Require().False(last.Before(first), "last seen time must not be before the first seen one")
+
+		summary = decode(s.writer.arr[1])
+		s.Require().Equal("b", summary["tenant_id"], "unexpected summary order")
+		s.Require().Equal(float64(1), summary[logger.RepeatCountKey], "unexpected repeat count")
+
+		s.writer.CleanUp()
+		for range 3 {
+			l.Error(ctx, "db down")
+		}
+		s.Require().Len(s.writer.arr, 3, "repeats must not be suppressed after close")
+	})
+
+	s.Run("window", func() {
+		w := newSyncWriter()
+		l, err := logger.NewLogger(
+			logger.WithSettings(logger.Settings{Level: "info", DedupWindow: "20ms"}),
+			logger.WithWriter(w),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+		defer func() { s.Require().NoError(l.Close(ctx), "got error, expected nil") }()
+		s.Require().Equal("20ms", l.Settings().DedupWindow, "unexpected effective settings")
+
+		l.Warn(ctx, "retrying")
+		l.Warn(ctx, "retrying")
+		s.Require().Eventually(func() bool {
+			return w.contains(`"repeat_count":1`)
+		}, time.Second, 5*time.Millisecond, "repeats must be summarized within the window")
+
+		// Records are written again after the window passes.
+		time.Sleep(40 * time.Millisecond)
+		l.Warn(ctx, "retrying")
+		s.Require().Len(w.lines(), 3, "unexpected amount of logs received")
+	})
+
+	s.Run("named loggers", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info", "dedup_window": "1h"}),
+			logger.WithWriter(s.writer),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		for range 3 {
+			l.Named("db").Error(ctx, "timeout")
+			l.Named("http").Error(ctx, "timeout")
+		}
+		s.Require().Len(s.writer.arr, 2, "records of different loggers must not be suppressed")
+		s.Require().Equal("db", decode(s.writer.arr[0])[logger.LoggerKey], "unexpected logger name")
+		s.Require().Equal("http", decode(s.writer.arr[1])[logger.LoggerKey], "unexpected logger name")
+
+		s.writer.CleanUp()
+		s.Require().NoError(l.Close(ctx), "got error, expected nil")
+		s.Require().Len(s.writer.arr, 2, "unexpected amount of summaries")
+		for i, name := range []string{"db", "http"} {
+			summary := decode(s.writer.arr[i])
+			s.Require().Equal(name, summary[logger.LoggerKey], "summary must keep the logger name")
+			s.Require().Equal(float64(2), summary[logger.RepeatCountKey], "unexpected repeat count")
+		}
+	})
+
+	s.Run("concurrent", func() {
+		w := newSyncWriter()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info", "dedup_window": "1h"}),
+			logger.WithWriter(w),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 100 {
+					l.Error(ctx, "dependency unavailable")
+				}
+			}()
+		}
+		wg.Wait()
+		s.Require().NoError(l.Close(ctx), "got error, expected nil")
+
+		lines := w.lines()
+		s.Require().Len(lines, 2, "unexpected amount of logs received")
+		s.Require().Equal(float64(799), decode([]byte(lines[1]))[logger.RepeatCountKey], "unexpected repeat count")
+	})
+
+	s.Run("invalid", func() {
+		_, err := logger.NewLogger(logger.WithConfig(map[string]any{"dedup_window": "-1s"}))
+		s.Require().ErrorIs(err, logger.ErrInvalidDedupOptions, "unexpected error")
+		_, err = logger.NewLogger(logger.WithConfig(map[string]any{"dedup_window": 10}))
+		s.Require().ErrorIs(err, logger.ErrWrongType, "unexpected error")
+	})
+}
@@ -3,6 +3,7 @@ package logkit
 import (
 	"log/slog"
 	"os"
+	"time"
 )
 
 const (
@@ -16,6 +17,34 @@ const (
 	DefaultLevelValue = slog.LevelError
 	// DefaultWriter is a default writer to use for logging.
 	DefaultWriter = "stdout"
+	// LoggerKey is a key used for the name of named loggers.
+	LoggerKey = "logger"
+	// StackKey is a key used for stack traces.
+	StackKey = "stack"
+	// ErrorKey is a key used for errors passed without a key, see Err.
+	ErrorKey = "error"
+	// TraceIDKey is a default key used for trace IDs, see WithTraceContext.
+	TraceIDKey = "trace_id"
+	// SpanIDKey is a default key used for span IDs, see WithTraceContext.
+	SpanIDKey = "span_id"
+	// TraceFlagsKey is a default key used for trace flags, see WithTraceContext.
+	TraceFlagsKey = "trace_flags"
+	// SampledMessageKey is a key used for the message of records suppressed by sampling, see WithSampling.
+	SampledMessageKey = "sampled_msg"
+	// SuppressedKey is a key used for the amount of records suppressed by sampling or dropped by rate limiting,
+	// see WithSampling and WithRateLimitReportInterval.
+	SuppressedKey = "suppressed"
+	// LimitedLevelKey is a key used for the level of records dropped by rate limiting,
+	// see WithRateLimitReportInterval.
+	LimitedLevelKey = "limited_level"
+	// RepeatCountKey is a key used for the amount of suppressed repeats, see WithConfig "dedup_window".
+	RepeatCountKey = "repeat_count"
+	// FirstSeenKey is a key used for the time of the first suppressed repeat, see WithConfig "dedup_window".
+	FirstSeenKey = "first_seen"
+	// LastSeenKey is a key used for the time of the last suppressed repeat, see WithConfig "dedup_window".
+	LastSeenKey = "last_seen"
+	// DefaultFatalTimeout is a default time limit for running fatal hooks.
+	DefaultFatalTimeout = 5 * time.Second
 )
 
 // DefaultWriterValue is a default writer value.
@@ -0,0 +1,101 @@
+package logkit
+
+import (
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// WithEnv applies configuration from environment variables. Each config field described in WithConfig
+// is read from PREFIX_LOG_<FIELD> variable, e.g. for "APP" prefix:
+//
+//	APP_LOG_LEVEL=debug
+//	APP_LOG_FORMAT=json
+//	APP_LOG_TIME_TEMPLATE="2006-01-02 15:04:05"
+//	APP_LOG_STREAM=file           // "log_stream" field
+//	APP_LOG_FILE_PATH=/var/log/app.log
+//	APP_LOG_MAX_SIZE_MB=100
+//	APP_LOG_COMPRESS=true
+//	APP_LOG_LEVELS=db=trace,http.client=warn
+//
+// Empty prefix results in LOG_<FIELD> variables. Unset and empty variables are ignored.
+// Numeric and boolean values are parsed with strconv, component levels are given as comma-separated
+// name=level pairs. Values which can not be parsed are reported as invalid types along with validation errors.
+func WithEnv(prefix string) Option {
+	return func(c *Config) error {
+		cfg, ve := readEnv(prefix)
+		return c.applyConfig(cfg, ve)
+	}
+}
+
+// readEnv reads config fields from the environment. Conversion failures are collected in the returned error.
+func readEnv(prefix string) (map[string]any, *ConfigError) {
+	cfg := make(map[string]any)
+	ve := &ConfigError{}
+
+	fields := make([]string, 0, len(configFields))
+	for field := range configFields {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	for _, field := range fields {
+		raw, ok := os.LookupEnv(envName(prefix, field))
+		if !ok || raw == "" {
+			continue
+		}
+
+		val, ok := parseEnvValue(raw, reflect.TypeOf(configFields[field]).Kind())
+		if !ok {
+			ve.addWrongType(field, raw)
+			continue
+		}
+		cfg[field] = val
+	}
+
+	return cfg, ve
+}
+
+// envName returns the name of the environment variable for the config field.
+func envName(prefix, field string) string {
+	name := "LOG_" + strings.ToUpper(strings.TrimPrefix(field, "log_"))
+	if prefix = strings.TrimSuffix(prefix, "_"); prefix != "" {
+		name = prefix + "_" + name
+	}
+	return name
+}
+
+// parseEnvValue converts the raw value to the given kind.
+func parseEnvValue(raw string, kind reflect.Kind) (any, bool) {
+	switch kind {
+	case reflect.Int:
+		v, err := strconv.Atoi(strings.TrimSpace(raw))
+		return v, err == nil
+	case reflect.Bool:
+		v, err := strconv.ParseBool(strings.TrimSpace(raw))
+		return v, err == nil
+	case reflect.Map:
+		return parseEnvMap(raw)
+	default:
+		return raw, true
+	}
+}
+
+// parseEnvMap parses comma-separated name=value pairs, e.g. "db=trace,http.client=warn".
+func parseEnvMap(raw string) (map[string]any, bool) {
+	res := make(map[string]any)
+	for pair := range strings.SplitSeq(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, false
+		}
+		res[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return res, true
+}
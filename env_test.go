@@ -0,0 +1,58 @@
+package logkit_test
+
+import (
+	"context"
+	"path/filepath"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestEnv() {
+	s.Run("applied", func() {
+		s.writer.CleanUp()
+		s.T().Setenv("APP_LOG_LEVEL", "debug")
+		s.T().Setenv("APP_LOG_FORMAT", "logfmt")
+		s.T().Setenv("APP_LOG_LEVELS", "db=trace, http=error")
+		s.T().Setenv("APP_LOG_ADD_SOURCE", "")
+
+		l, err := logger.NewLogger(logger.WithEnv("APP"), logger.WithWriter(s.writer))
+		s.Require().NoError(err, "got error, expected nil")
+
+		ctx := context.Background()
+		l.Debug(ctx, "debug")
+		l.Named("db").Trace(ctx, "trace")
+		l.Named("http").Warn(ctx, "warn")
+		s.Require().Len(s.writer.arr, 2, "unexpected amount of logs received")
+		s.Require().Contains(string(s.writer.arr[0]), "level=DEBUG msg=debug", "unexpected log entry")
+		s.Require().Contains(string(s.writer.arr[1]), "level=TRACE msg=trace", "unexpected log entry")
+		s.Require().Equal("DEBUG", l.Level(), "unexpected level")
+	})
+
+	s.Run("file stream", func() {
+		path := filepath.Join(s.T().TempDir(), "app.log")
+		s.T().Setenv("LOG_STREAM", "file")
+		s.T().Setenv("LOG_FILE_PATH", path)
+		s.T().Setenv("LOG_MAX_SIZE_MB", "10")
+		s.T().Setenv("LOG_COMPRESS", "true")
+
+		l, err := logger.NewLogger(logger.WithEnv(""))
+		s.Require().NoError(err, "got error, expected nil")
+		l.Info(context.Background(), "to file")
+		s.Require().NoError(l.Close(context.Background()), "got error, expected nil")
+		s.Require().FileExists(path, "log file was not created")
+	})
+
+	s.Run("invalid values", func() {
+		s.T().Setenv("APP_LOG_LEVEL", "loud")
+		s.T().Setenv("APP_LOG_FORMAT", "xml")
+		s.T().Setenv("APP_LOG_MAX_SIZE_MB", "ten")
+		s.T().Setenv("APP_LOG_COMPRESS", "maybe")
+		s.T().Setenv("APP_LOG_LEVELS", "db")
+
+		_, err := logger.NewLogger(logger.WithEnv("APP_"))
+		s.Require().Error(err, "got nil, expected error")
+		for _, field := range []string{"level", "format", "max_size_mb", "compress", "levels"} {
+			s.Require().Contains(err.Error(), field, "error does not mention the field")
+		}
+	})
+}
@@ -0,0 +1,213 @@
+package logkit
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// maxErrorDepth limits the depth of rendered error chains, protecting from cyclic chains.
+const maxErrorDepth = 16
+
+// Err returns an attribute with the error under ErrorKey. The error is written as a group:
+//   - msg: the error message.
+//   - type: the concrete type of the error, e.g. "*fs.PathError".
+//   - stack: the stack trace, if the error carries one (see below).
+//   - cause: the error returned by Unwrap() error, rendered the same way.
+//   - causes: the errors returned by Unwrap() []error, e.g. by errors.Join, keyed by their indexes.
+//
+// Supported stack trace carriers are errors with StackTrace() method returning a slice of program counters
+// (as github.com/pkg/errors does), Callers() []uintptr method or StackTrace() Stack method.
+//
+// Error values passed to logging methods are rendered the same way automatically, keeping their keys:
+//
+//	logger.Error(ctx, "request failed", "cause", err) // → "cause":{"msg":"...","type":"..."}
+//	logger.Error(ctx, "request failed", err)          // → "error":{"msg":"...","type":"..."}
+func Err(err error) slog.Attr {
+	return errAttr(ErrorKey, err)
+}
+
+// errAttr returns an attribute with the error under the given key.
+func errAttr(key string, err error) slog.Attr {
+	if isNilError(err) {
+		return slog.Any(key, nil)
+	}
+	return slog.Any(key, errorValue{err})
+}
+
+// errorValue is a slog.LogValuer which renders the error as a group.
+type errorValue struct {
+	err error
+}
+
+// LogValue implements slog.LogValuer.
+func (v errorValue) LogValue() slog.Value {
+	return errorGroup(v.err, 0)
+}
+
+// Error implements error, so handlers unaware of errorValue still treat it as an error.
+func (v errorValue) Error() string {
+	return errorMessage(v.err)
+}
+
+// String returns a multi-line description of the error chain, used in console format.
+func (v errorValue) String() string {
+	var b strings.Builder
+	writeErrorDetails(&b, v.err, "", 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// errorGroup returns the group value describing the error and its chain.
+func errorGroup(err error, depth int) slog.Value {
+	attrs := []slog.Attr{
+		slog.String("msg", errorMessage(err)),
+		slog.String("type", fmt.Sprintf("%T", err)),
+	}
+	if stack := errorStack(err); len(stack) > 0 {
+		attrs = append(attrs, slog.Any(StackKey, stack))
+	}
+	if depth >= maxErrorDepth {
+		return slog.GroupValue(attrs...)
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := u.Unwrap(); !isNilError(cause) {
+			attrs = append(attrs, slog.Attr{Key: "cause", Value: errorGroup(cause, depth+1)})
+		}
+	case interface{ Unwrap() []error }:
+		causes := make([]slog.Attr, 0, len(u.Unwrap()))
+		for i, cause := range u.Unwrap() {
+			if !isNilError(cause) {
+				causes = append(causes, slog.Attr{Key: strconv.Itoa(i), Value: errorGroup(cause, depth+1)})
+			}
+		}
+		if len(causes) > 0 {
+			attrs = append(attrs, slog.Attr{Key: "causes", Value: slog.GroupValue(causes...)})
+		}
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// writeErrorDetails writes the error chain as indented lines.
+func writeErrorDetails(b *strings.Builder, err error, indent string, depth int) {
+	fmt.Fprintf(b, "%s%s (%T)\n", indent, errorMessage(err), err)
+	if stack := errorStack(err); len(stack) > 0 {
+		for _, l := range strings.Split(stack.String(), "\n") {
+			b.WriteString(indent + "\t" + l + "\n")
+		}
+	}
+	if depth >= maxErrorDepth {
+		return
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := u.Unwrap(); !isNilError(cause) {
+			b.WriteString(indent + "caused by: ")
+			writeErrorDetails(b, cause, indent, depth+1)
+		}
+	case interface{ Unwrap() []error }:
+		for _, cause := range u.Unwrap() {
+			if !isNilError(cause) {
+				b.WriteString(indent + "caused by: ")
+				writeErrorDetails(b, cause, indent+"  ", depth+1)
+			}
+		}
+	}
+}
+
+// isNilError reports whether the error is nil, including typed nil pointers, e.g. a nil *MyError.
+func isNilError(err error) bool {
+	if err == nil {
+		return true
+	}
+	v := reflect.ValueOf(err)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// errorMessage returns the message of the error, recovering from a panic in its Error method.
+func errorMessage(err error) (msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = fmt.Sprintf("!PANIC: %v", r)
+		}
+	}()
+	return err.Error()
+}
+
+// errorStack returns the stack trace carried by the error itself (not by its chain), or nil.
+func errorStack(err error) Stack {
+	switch e := err.(type) {
+	case interface{ StackTrace() Stack }:
+		return e.StackTrace()
+	case interface{ Callers() []uintptr }:
+		return stackFromPCs(e.Callers())
+	}
+
+	// Errors of github.com/pkg/errors return errors.StackTrace, which is a slice of uintptr-based frames.
+	// Reflection is used to avoid the dependency.
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil
+	}
+	out := method.Type().Out(0)
+	if out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	frames := method.Call(nil)[0]
+	pcs := make([]uintptr, frames.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(frames.Index(i).Uint())
+	}
+	return stackFromPCs(pcs)
+}
+
+// convertErrors replaces error values in logging arguments with error attributes, see Err.
+// Arguments are expected in the same form as slog.Logger methods accept them.
+func convertErrors(args []any) []any {
+	var res []any // Allocated only if there are errors to convert.
+	for i := 0; i < len(args); i++ {
+		var (
+			converted slog.Attr
+			consumed  = 1
+			found     bool
+		)
+
+		switch a := args[i].(type) {
+		case string:
+			if i+1 < len(args) {
+				consumed = 2
+				if err, ok := args[i+1].(error); ok {
+					converted, found = errAttr(a, err), true
+				}
+			}
+		case slog.Attr:
+			if err, ok := a.Value.Any().(error); ok && a.Value.Kind() == slog.KindAny {
+				converted, found = errAttr(a.Key, err), true
+			}
+		case error:
+			converted, found = errAttr(ErrorKey, a), true
+		}
+
+		if found && res == nil {
+			res = append(make([]any, 0, len(args)), args[:i]...)
+		}
+		switch {
+		case found:
+			res = append(res, converted)
+		case res != nil:
+			res = append(res, args[i:i+consumed]...)
+		}
+		i += consumed - 1
+	}
+
+	if res == nil {
+		return args
+	}
+	return res
+}
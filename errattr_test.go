@@ -0,0 +1,165 @@
+package logkit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"runtime"
+
+	logger "github.com/Averlex/logkit"
+)
+
+// stackError is an error carrying the stack trace of its creation.
+type stackError struct {
+	msg string
+	pcs []uintptr
+}
+
+func newStackError(msg string) *stackError {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	return &stackError{msg, pcs[:n]}
+}
+
+func (e *stackError) Error() string      { return e.msg }
+func (e *stackError) Callers() []uintptr { return e.pcs }
+
+func (s *LoggerTestSuite) TestErrorAttributes() {
+	pathErr := &fs.PathError{Op: "open", Path: "/tmp/x", Err: fs.ErrNotExist}
+	wrapped := fmt.Errorf("load config: %w", pathErr)
+
+	testCases := []struct {
+		name     string
+		args     []any
+		key      string
+		expected map[string]any
+	}{
+		{
+			name: "err helper",
+			args: []any{logger.Err(errors.New("boom"))},
+			key:  "error",
+			expected: map[string]any{
+				"msg":  "boom",
+				"type": "*errors.errorString",
+			},
+		},
+		{
+			name: "wrapped chain",
+			args: []any{"cause", wrapped},
+			key:  "cause",
+			expected: map[string]any{
+				"msg":  "load config: open /tmp/x: file does not exist",
+				"type": "*fmt.wrapError",
+				"cause": map[string]any{
+					"msg":  "open /tmp/x: file does not exist",
+					"type": "*fs.PathError",
+					"cause": map[string]any{
+						"msg":  "file does not exist",
+						"type": "*errors.errorString",
+					},
+				},
+			},
+		},
+		{
+			name: "joined errors",
+			args: []any{slog.Any("failure", errors.Join(errors.New("first"), errors.New("second")))},
+			key:  "failure",
+			expected: map[string]any{
+				"msg":  "first\nsecond",
+				"type": "*errors.joinError",
+				"causes": map[string]any{
+					"0": map[string]any{"msg": "first", "type": "*errors.errorString"},
+					"1": map[string]any{"msg": "second", "type": "*errors.errorString"},
+				},
+			},
+		},
+		{
+			name: "bare error",
+			args: []any{"id", 1, errors.New("boom")},
+			key:  "error",
+			expected: map[string]any{
+				"msg":  "boom",
+				"type": "*errors.errorString",
+			},
+		},
+		{
+			name:     "nil error",
+			args:     []any{logger.Err(nil)},
+			key:      "error",
+			expected: nil,
+		},
+		{
+			name:     "typed nil error",
+			args:     []any{"err", (*stackError)(nil)},
+			key:      "err",
+			expected: nil,
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			s.writer.CleanUp()
+			l, err := logger.NewLogger(logger.WithConfig(map[string]any{"level": "info"}), logger.WithWriter(s.writer))
+			s.Require().NoError(err, "got error, expected nil")
+
+			l.Error(context.Background(), "failed", tC.args...)
+			s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+
+			var logData map[string]any
+			s.Require().NoError(json.Unmarshal(s.writer.arr[0], &logData), "failed to unmarshal log entry")
+			if tC.expected == nil {
+				s.Require().Nil(logData[tC.key], "unexpected error value")
+				return
+			}
+			s.Require().Equal(tC.expected, logData[tC.key], "unexpected error value")
+		})
+	}
+
+	s.Run("typed nil in all formats", func() {
+		var typedNil *stackError
+		for format, expected := range map[string]string{
+			"json":    `"err":null`,
+			"text":    `err=<nil>`,
+			"logfmt":  `err=<nil>`,
+			"console": `err=<nil>`,
+		} {
+			s.writer.CleanUp()
+			l, err := logger.NewLogger(
+				logger.WithConfig(map[string]any{"level": "info", "format": format}),
+				logger.WithWriter(s.writer),
+			)
+			s.Require().NoError(err, "got error, expected nil")
+
+			s.Require().NotPanics(func() {
+				l.Error(context.Background(), "failed", "err", typedNil)
+				l.Error(context.Background(), "failed", typedNil, logger.Err(typedNil))
+			}, "unexpected panic in %s format", format)
+			s.Require().Len(s.writer.arr, 2, "unexpected amount of logs received")
+			s.Require().Contains(string(s.writer.arr[0]), expected, "unexpected error value in %s format", format)
+			s.Require().NotContains(string(s.writer.arr[1]), "panic", "unexpected error value in %s format", format)
+		}
+	})
+
+	s.Run("stack carrier", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(logger.WithConfig(map[string]any{"level": "info"}), logger.WithWriter(s.writer))
+		s.Require().NoError(err, "got error, expected nil")
+
+		l.With("request_error", newStackError("boom")).Error(context.Background(), "failed")
+		s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+
+		var entry struct {
+			Err struct {
+				Msg   string              `json:"msg"`
+				Stack []logger.StackFrame `json:"stack"`
+			} `json:"request_error"`
+		}
+		s.Require().NoError(json.Unmarshal(s.writer.arr[0], &entry), "failed to unmarshal log entry")
+		s.Require().Equal("boom", entry.Err.Msg, "unexpected error message")
+		s.Require().NotEmpty(entry.Err.Stack, "expected stack trace")
+		s.Require().Contains(entry.Err.Stack[0].Function, "TestErrorAttributes", "unexpected stack trace")
+	})
+}
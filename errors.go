@@ -1,30 +1,170 @@
 package logkit
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
-// validationError accumulates multiple validation failures (invalid types or values) during config parsing.
-// Used internally by WithConfig to report all issues at once.
-type validationError struct {
-	invalidTypes  []string
-	invalidValues []string
+// Sentinel errors describing problems of config fields. Errors returned by NewLogger for an invalid config
+// match them via errors.Is, if any of the fields has the corresponding problem:
+//
+//	if errors.Is(err, logkit.ErrInvalidLevel) { ... }
+var (
+	// ErrWrongType is a problem of fields with values of unexpected types.
+	ErrWrongType = errors.New("wrong type")
+	// ErrUnknownKey is a problem of unsupported fields, reported in strict mode only.
+	ErrUnknownKey = errors.New("unknown key")
+	// ErrInvalidLevel is a problem of unknown log levels.
+	ErrInvalidLevel = errors.New("invalid log level")
+	// ErrInvalidFormat is a problem of unknown log formats.
+	ErrInvalidFormat = errors.New("invalid log format")
+	// ErrInvalidTimeTemplate is a problem of time templates which can not be parsed back.
+	ErrInvalidTimeTemplate = errors.New("invalid time template")
+	// ErrInvalidLogStream is a problem of unknown log streams.
+	ErrInvalidLogStream = errors.New("invalid log stream")
+	// ErrInvalidFileOptions is a problem of log file options, e.g. negative sizes or missing file path.
+	ErrInvalidFileOptions = errors.New("invalid log file options")
+	// ErrInvalidSourcePath is a problem of unknown source path forms.
+	ErrInvalidSourcePath = errors.New("invalid source path")
+	// ErrInvalidDedupOptions is a problem of deduplication options, e.g. negative window.
+	ErrInvalidDedupOptions = errors.New("invalid deduplication options")
+)
+
+// ConfigErrorKind is a kind of the config field problem.
+type ConfigErrorKind int
+
+// Supported kinds of config field problems.
+const (
+	// KindWrongType means the value has an unexpected type.
+	KindWrongType ConfigErrorKind = iota + 1
+	// KindInvalidValue means the value has the expected type, but it is not acceptable.
+	KindInvalidValue
+	// KindUnknownKey means the field is not supported.
+	KindUnknownKey
+)
+
+// String returns the name of the kind used in error messages.
+func (k ConfigErrorKind) String() string {
+	switch k {
+	case KindWrongType:
+		return "invalid_type"
+	case KindInvalidValue:
+		return "invalid_value"
+	case KindUnknownKey:
+		return "unknown_key"
+	default:
+		return fmt.Sprintf("ConfigErrorKind(%d)", int(k))
+	}
 }
 
-// Error returns a string representation of validation error.
-func (e *validationError) Error() string {
+// FieldError describes a problem of a single config field.
+type FieldError struct {
+	Field    string          // Field name, nested fields are joined with dots, e.g. "levels.db".
+	Kind     ConfigErrorKind // Kind of the problem.
+	Value    any             // Offending value, nil if the field is missing.
+	Allowed  []string        // Allowed values, if there is a fixed set of them.
+	Position string          // Position of the field in the config file, e.g. "logkit.yaml:3", if known.
+	Err      error           // Sentinel error describing the problem, e.g. ErrInvalidLevel.
+}
+
+// Error returns a string representation of the field error.
+func (e FieldError) Error() string {
 	var b strings.Builder
-	if len(e.invalidTypes) > 0 {
-		b.WriteString("invalid_type=" + strings.Join(e.invalidTypes, ","))
+	b.WriteString(e.Field)
+	if e.Position != "" {
+		b.WriteString(" (" + e.Position + ")")
 	}
-	if len(e.invalidValues) > 0 {
-		if b.Len() > 0 {
-			b.WriteString(", ")
+	fmt.Fprintf(&b, ": %v %#v", e.Err, e.Value)
+	if len(e.Allowed) > 0 {
+		b.WriteString(", allowed: " + strings.Join(e.Allowed, ", "))
+	}
+	return b.String()
+}
+
+// Unwrap returns the sentinel error of the problem.
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ConfigError accumulates problems of config fields, so all of them are reported at once.
+// It is returned by NewLogger wrapped, use errors.As to inspect it:
+//
+//	var cfgErr *logkit.ConfigError
+//	if errors.As(err, &cfgErr) {
+//		for _, f := range cfgErr.Fields { ... }
+//	}
+type ConfigError struct {
+	Fields []FieldError
+
+	positions map[string]string // Field -> its position in the config file, e.g. "logkit.yaml:3".
+}
+
+// Error returns a string representation of the config error, listing field names grouped by kinds:
+// "invalid_type=max_age, invalid_value=level,format".
+func (e *ConfigError) Error() string {
+	var b strings.Builder
+	for _, kind := range []ConfigErrorKind{KindWrongType, KindInvalidValue, KindUnknownKey} {
+		first := true
+		for _, f := range e.Fields {
+			if f.Kind != kind {
+				continue
+			}
+			switch {
+			case first && b.Len() > 0:
+				b.WriteString(", " + kind.String() + "=")
+			case first:
+				b.WriteString(kind.String() + "=")
+			default:
+				b.WriteByte(',')
+			}
+			first = false
+
+			b.WriteString(f.Field)
+			if f.Position != "" {
+				b.WriteString(" (" + f.Position + ")")
+			}
 		}
-		b.WriteString("invalid_value=" + strings.Join(e.invalidValues, ","))
 	}
 	return b.String()
 }
 
+// Unwrap returns the field errors, so errors.Is matches their sentinel errors.
+func (e *ConfigError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, f := range e.Fields {
+		errs[i] = f
+	}
+	return errs
+}
+
+// addWrongType adds the wrong type problem of the field, unless it is already reported.
+func (e *ConfigError) addWrongType(field string, value any) {
+	e.add(FieldError{Field: field, Kind: KindWrongType, Value: value, Err: ErrWrongType})
+}
+
+// addInvalidValue adds the invalid value problem of the field.
+func (e *ConfigError) addInvalidValue(field string, value any, err error, allowed ...string) {
+	e.add(FieldError{Field: field, Kind: KindInvalidValue, Value: value, Allowed: allowed, Err: err})
+}
+
+// addUnknownKey adds the unknown key problem of the field.
+func (e *ConfigError) addUnknownKey(field string, value any) {
+	e.add(FieldError{Field: field, Kind: KindUnknownKey, Value: value, Err: ErrUnknownKey})
+}
+
+// add adds the problem of the field, unless the same kind of problem is already reported for it.
+func (e *ConfigError) add(f FieldError) {
+	for _, existing := range e.Fields {
+		if existing.Field == f.Field && existing.Kind == f.Kind {
+			return
+		}
+	}
+	f.Position = e.positions[f.Field]
+	e.Fields = append(e.Fields, f)
+}
+
 // hasErrors returns true if there are any validation errors.
-func (e *validationError) hasErrors() bool {
-	return len(e.invalidTypes) > 0 || len(e.invalidValues) > 0
+func (e *ConfigError) hasErrors() bool {
+	return len(e.Fields) > 0
 }
@@ -0,0 +1,94 @@
+package logkit_test
+
+import (
+	"errors"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestConfigError() {
+	s.Run("fields", func() {
+		_, err := logger.NewLogger(logger.WithConfig(map[string]any{
+			"level":       123,
+			"format":      "xml",
+			"source_path": "relative",
+			"levels":      map[string]any{"db": "loud"},
+		}))
+		s.Require().Error(err, "got nil, expected error")
+		s.Require().EqualError(err, "logger initialization failed: config data is invalid: "+
+			"invalid_type=level, invalid_value=levels.db,format,source_path", "unexpected error message")
+
+		var cfgErr *logger.ConfigError
+		s.Require().ErrorAs(err, &cfgErr, "expected ConfigError")
+		s.Require().Len(cfgErr.Fields, 4, "unexpected amount of field errors")
+
+		byField := make(map[string]logger.FieldError)
+		for _, f := range cfgErr.Fields {
+			byField[f.Field] = f
+		}
+
+		s.Require().Equal(logger.KindWrongType, byField["level"].Kind, "unexpected kind")
+		s.Require().Equal(123, byField["level"].Value, "unexpected value")
+		s.Require().ErrorIs(byField["level"], logger.ErrWrongType, "unexpected sentinel")
+
+		s.Require().Equal(logger.KindInvalidValue, byField["format"].Kind, "unexpected kind")
+		s.Require().Equal("xml", byField["format"].Value, "unexpected value")
+		s.Require().Equal([]string{"json", "text", "logfmt", "console"}, byField["format"].Allowed, "unexpected allowed values")
+
+		s.Require().Equal("loud", byField["levels.db"].Value, "unexpected value")
+		s.Require().Equal(
+			[]string{"trace", "debug", "verbose", "info", "warn", "error", "fatal"},
+			byField["levels.db"].Allowed,
+			"unexpected allowed values",
+		)
+
+		s.Require().ErrorIs(err, logger.ErrWrongType, "unexpected sentinel")
+		s.Require().ErrorIs(err, logger.ErrInvalidFormat, "unexpected sentinel")
+		s.Require().ErrorIs(err, logger.ErrInvalidLevel, "unexpected sentinel")
+		s.Require().ErrorIs(err, logger.ErrInvalidSourcePath, "unexpected sentinel")
+		s.Require().NotErrorIs(err, logger.ErrInvalidTimeTemplate, "unexpected sentinel")
+		s.Require().Contains(byField["format"].Error(), `format: invalid log format "xml"`, "unexpected field error message")
+	})
+
+	s.Run("file positions and unknown keys", func() {
+		path := s.writeConfigFile("logkit.yaml", "level: info\nmax_age: soon\nlevl: debug\n")
+		_, err := logger.NewLogger(logger.WithConfigFile(path, true))
+
+		var cfgErr *logger.ConfigError
+		s.Require().ErrorAs(err, &cfgErr, "expected ConfigError")
+		s.Require().Equal([]logger.FieldError{
+			{
+				Field:    "levl",
+				Kind:     logger.KindUnknownKey,
+				Value:    "debug",
+				Position: path + ":3",
+				Err:      logger.ErrUnknownKey,
+			},
+			{
+				Field:    "max_age",
+				Kind:     logger.KindInvalidValue,
+				Value:    "soon",
+				Position: path + ":2",
+				Err:      logger.ErrInvalidFileOptions,
+			},
+		}, cfgErr.Fields, "unexpected field errors")
+		s.Require().ErrorIs(err, logger.ErrUnknownKey, "unexpected sentinel")
+	})
+
+	s.Run("env", func() {
+		s.T().Setenv("APP_LOG_MAX_BACKUPS", "many")
+		_, err := logger.NewLogger(logger.WithEnv("APP"))
+
+		var cfgErr *logger.ConfigError
+		s.Require().ErrorAs(err, &cfgErr, "expected ConfigError")
+		s.Require().Len(cfgErr.Fields, 1, "unexpected amount of field errors")
+		s.Require().Equal("max_backups", cfgErr.Fields[0].Field, "unexpected field")
+		s.Require().Equal("many", cfgErr.Fields[0].Value, "unexpected value")
+	})
+
+	s.Run("set level", func() {
+		l, err := logger.NewLogger()
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().True(errors.Is(l.SetLevel("loud"), logger.ErrInvalidLevel), "unexpected sentinel")
+	})
+}
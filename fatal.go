@@ -0,0 +1,106 @@
+package logkit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+)
+
+// fatalConfig defines the behavior of Logger.Fatal after the record is logged.
+type fatalConfig struct {
+	hooks    []func(ctx context.Context) error
+	timeout  time.Duration
+	exitFunc func(code int)
+}
+
+// WithFatalHooks registers hooks which are run by Logger.Fatal after the record is logged and before the exit.
+// Hooks are run sequentially in the order of registration and receive a context which is done once
+// the fatal timeout expires, see WithFatalTimeout. Hook errors are logged with level Error.
+//
+// Subsequent calls append hooks to the already registered ones.
+func WithFatalHooks(hooks ...func(ctx context.Context) error) Option {
+	return func(c *Config) error {
+		for _, hook := range hooks {
+			if hook == nil {
+				return errors.New("expected fatal hook, got nil")
+			}
+		}
+
+		c.fatal.hooks = append(c.fatal.hooks, hooks...)
+		return nil
+	}
+}
+
+// WithFatalTimeout sets the time limit for running fatal hooks. The same limit applies to writing
+// the queued records and closing the logger afterwards. Default is DefaultFatalTimeout.
+func WithFatalTimeout(timeout time.Duration) Option {
+	return func(c *Config) error {
+		if timeout <= 0 {
+			return errors.New("fatal timeout must be positive")
+		}
+
+		c.fatal.timeout = timeout
+		return nil
+	}
+}
+
+// WithExitFunc replaces os.Exit called by Logger.Fatal. It is mostly useful in tests.
+// If the function returns, Logger.Fatal returns as well.
+func WithExitFunc(exitFunc func(code int)) Option {
+	return func(c *Config) error {
+		if exitFunc == nil {
+			return errors.New("expected exit function, got nil")
+		}
+
+		c.fatal.exitFunc = exitFunc
+		return nil
+	}
+}
+
+// exit runs fatal hooks, closes the logger and calls the exit function.
+// It must be called directly by Fatal, see log.
+func (logg Logger) exit(ctx context.Context) {
+	// The caller context might be already cancelled, but its values are still useful for hooks and logging.
+	ctx = context.WithoutCancel(ctx)
+
+	if len(logg.fatal.hooks) > 0 {
+		hooksCtx, cancel := context.WithTimeout(ctx, logg.fatal.timeout)
+		if err := runFatalHooks(hooksCtx, logg.fatal.hooks); err != nil {
+			// Skipping exit, so the source and the stack trace point at the caller of Fatal.
+			logg.callerSkip++
+			logg.log(ctx, LevelError, "fatal hooks failed", "error", err)
+		}
+		cancel()
+	}
+
+	closeCtx, cancel := context.WithTimeout(ctx, logg.fatal.timeout)
+	_ = logg.Close(closeCtx)
+	cancel()
+
+	exitFunc := logg.fatal.exitFunc
+	if exitFunc == nil {
+		exitFunc = os.Exit
+	}
+	exitFunc(1)
+}
+
+// runFatalHooks runs hooks sequentially until all of them are finished or ctx is done.
+// Returns joined hook errors or ctx.Err() if hooks did not finish in time.
+func runFatalHooks(ctx context.Context, hooks []func(ctx context.Context) error) error {
+	done := make(chan error, 1)
+	go func() {
+		errs := make([]error, 0, len(hooks))
+		for _, hook := range hooks {
+			errs = append(errs, hook(ctx))
+		}
+		done <- errors.Join(errs...)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
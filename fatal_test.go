@@ -0,0 +1,97 @@
+package logkit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestFatal() {
+	s.Run("hooks and exit", func() {
+		s.writer.CleanUp()
+		var calls []string
+		exitCode := -1
+		l, err := logger.NewLogger(
+			logger.WithWriter(s.writer),
+			logger.WithAsync(10, logger.OverflowBlock),
+			logger.WithFatalHooks(
+				func(context.Context) error { calls = append(calls, "first"); return nil },
+				func(context.Context) error { calls = append(calls, "second"); return errors.New("hook error") },
+			),
+			logger.WithExitFunc(func(code int) { exitCode = code }),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		l.Fatal(context.Background(), "fatal")
+		s.Require().Equal(1, exitCode, "unexpected exit code")
+		s.Require().Equal([]string{"first", "second"}, calls, "unexpected hook calls")
+		// Queued records are written before the exit.
+		s.Require().Len(s.writer.arr, 2, "unexpected amount of logs received")
+
+		entry, err := decodeJSON(s.writer.arr[0])
+		s.Require().NoError(err, "failed to unmarshal log entry")
+		s.Require().Equal("FATAL", entry.Level, "unexpected log level")
+		entry, err = decodeJSON(s.writer.arr[1])
+		s.Require().NoError(err, "failed to unmarshal log entry")
+		s.Require().Equal("fatal hooks failed", entry.Msg, "unexpected log message")
+	})
+
+	s.Run("hook error source", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithWriter(s.writer),
+			logger.WithSource(),
+			logger.WithStacktrace(logger.LevelError),
+			logger.WithFatalHooks(func(context.Context) error { return errors.New("hook error") }),
+			logger.WithExitFunc(func(int) {}),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		l.Fatal(context.Background(), "fatal")
+		line := currentLine() - 1
+		s.Require().Len(s.writer.arr, 2, "unexpected amount of logs received")
+
+		for _, data := range s.writer.arr {
+			var entry struct {
+				sourceEntry
+				Stack []logger.StackFrame `json:"stack"`
+			}
+			s.Require().NoError(json.Unmarshal(data, &entry), "failed to unmarshal log entry")
+			s.Require().Equal(line, entry.Source.Line, "source must point at the caller of Fatal")
+			s.Require().Contains(entry.Source.File, "fatal_test.go", "source must point at the caller of Fatal")
+			s.Require().NotEmpty(entry.Stack, "expected stack trace")
+			s.Require().Equal(line, entry.Stack[0].Line, "stack must start at the caller of Fatal")
+		}
+	})
+
+	s.Run("hook timeout", func() {
+		s.writer.CleanUp()
+		exited := false
+		l, err := logger.NewLogger(
+			logger.WithWriter(s.writer),
+			logger.WithFatalTimeout(20*time.Millisecond),
+			logger.WithFatalHooks(func(context.Context) error { select {} }),
+			logger.WithExitFunc(func(int) { exited = true }),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		start := time.Now()
+		l.Fatal(context.Background(), "fatal")
+		s.Require().True(exited, "exit function was not called")
+		s.Require().Less(time.Since(start), time.Second, "hooks were not interrupted")
+	})
+
+	s.Run("invalid options", func() {
+		for _, opt := range []logger.Option{
+			logger.WithFatalHooks(nil),
+			logger.WithFatalTimeout(0),
+			logger.WithExitFunc(nil),
+		} {
+			_, err := logger.NewLogger(opt)
+			s.Require().Error(err, "got nil, expected error")
+		}
+	})
+}
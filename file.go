@@ -0,0 +1,370 @@
+package logkit
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Supported values of the "rotation" config field.
+const (
+	// RotationNone disables time-based rotation.
+	RotationNone = ""
+	// RotationHourly rotates the file at the beginning of every hour.
+	RotationHourly = "hourly"
+	// RotationDaily rotates the file at midnight.
+	RotationDaily = "daily"
+)
+
+// backupTimeFormat is a time format used in the names of rotated files.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// FileOptions defines a configuration of RotatingFile.
+type FileOptions struct {
+	// Path is a path to the log file. Missing directories are created.
+	Path string
+	// MaxSizeMB is a maximum size of the file in megabytes before it is rotated. Zero disables size-based rotation.
+	MaxSizeMB int
+	// MaxAge is a maximum age of rotated files. Older files are removed. Zero disables age-based removal.
+	MaxAge time.Duration
+	// MaxBackups is a maximum number of rotated files to keep. Zero keeps all of them.
+	MaxBackups int
+	// Rotation is a time-based rotation interval: RotationNone, RotationHourly or RotationDaily.
+	Rotation string
+	// Compress enables gzip compression of rotated files.
+	Compress bool
+}
+
+// RotatingFile is an io.WriteCloser which writes to a file and rotates it by size and/or time.
+//
+// Rotated files are renamed to "<name>-<timestamp><ext>", e.g. "app-2025-04-05T10-00-00.000.log",
+// and optionally compressed with gzip. Files rotated within the same millisecond get a sequence number
+// after the timestamp, e.g. "app-2025-04-05T10-00-00.000.1.log". Compression and removal of old files happen in background.
+//
+// RotatingFile is safe for concurrent use.
+type RotatingFile struct {
+	opts FileOptions
+
+	mu           sync.Mutex
+	file         *os.File
+	size         int64
+	nextRotation time.Time
+	closed       bool
+
+	// Background compression and cleanup of rotated files.
+	postRotate chan struct{}
+	wg         sync.WaitGroup
+}
+
+// NewRotatingFile opens the file for appending and returns a new RotatingFile.
+func NewRotatingFile(opts FileOptions) (*RotatingFile, error) {
+	if opts.Path == "" {
+		return nil, errors.New("file path is empty")
+	}
+	if opts.MaxSizeMB < 0 || opts.MaxBackups < 0 || opts.MaxAge < 0 {
+		return nil, errors.New("file limits must not be negative")
+	}
+	switch opts.Rotation {
+	case RotationNone, RotationHourly, RotationDaily:
+	default:
+		return nil, fmt.Errorf("unknown rotation interval: %q", opts.Rotation)
+	}
+
+	f := &RotatingFile{opts: opts, postRotate: make(chan struct{}, 1)}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	f.wg.Add(1)
+	go f.runPostRotate()
+
+	return f, nil
+}
+
+// Write implements io.Writer. The file is rotated before writing if the write exceeds the size limit
+// or the rotation interval has passed.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+
+	maxSize := int64(f.opts.MaxSizeMB) * 1024 * 1024
+	sizeExceeded := maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > maxSize
+	intervalPassed := !f.nextRotation.IsZero() && !time.Now().Before(f.nextRotation)
+	var rotateErr error
+	if sizeExceeded || intervalPassed {
+		rotateErr = f.rotate()
+	}
+	// The file is reopened after a failed rotation or reopening, so a transient failure does not lose the later writes.
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, errors.Join(rotateErr, err)
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, errors.Join(rotateErr, err)
+}
+
+// Rotate forces the file rotation.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+	return f.rotate()
+}
+
+// Sync commits the current contents of the file to stable storage.
+func (f *RotatingFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+	if f.file == nil {
+		return errors.New("log file is not open")
+	}
+	return f.file.Sync()
+}
+
+// Close closes the file and waits for the background compression and cleanup to finish.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	f.closed = true
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+	}
+	close(f.postRotate)
+	f.mu.Unlock()
+
+	f.wg.Wait()
+	return err
+}
+
+// open opens the file for appending and resets the rotation state.
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.opts.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(f.opts.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.nextRotation = nextRotationTime(time.Now(), f.opts.Rotation)
+	return nil
+}
+
+// rotate renames the current file to a backup name, opens a new one and schedules the post-rotation tasks.
+// If the file can not be renamed, the current one is reopened to keep writing to it. If no file can be opened,
+// f.file is left nil and the file is reopened on the next write. The caller must hold f.mu.
+func (f *RotatingFile) rotate() error {
+	if f.file != nil {
+		err := f.file.Close()
+		f.file = nil
+		if err != nil {
+			return fmt.Errorf("failed to close log file: %w", err)
+		}
+	}
+	if err := os.Rename(f.opts.Path, f.backupName(time.Now())); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Join(fmt.Errorf("failed to rename log file: %w", err), f.open())
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	// The task is already pending otherwise: it handles all rotated files at once.
+	select {
+	case f.postRotate <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// backupName returns a name of the rotated file for the given time. A sequence number is added if the name
+// is already taken by another backup, compressed or not, so rotations within the same millisecond do not
+// overwrite each other.
+func (f *RotatingFile) backupName(t time.Time) string {
+	dir, prefix, ext := f.nameParts()
+	name := prefix + t.Format(backupTimeFormat)
+	for seq := 1; ; seq++ {
+		path := filepath.Join(dir, name+ext)
+		if !fileExists(path) && !fileExists(path+".gz") {
+			return path
+		}
+		name = prefix + t.Format(backupTimeFormat) + "." + strconv.Itoa(seq)
+	}
+}
+
+// fileExists reports whether the file exists. On other errors the file is reported as missing:
+// the rename fails the same way then.
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// nameParts splits the file path into the directory, backup name prefix and extension.
+func (f *RotatingFile) nameParts() (dir, prefix, ext string) {
+	dir = filepath.Dir(f.opts.Path)
+	base := filepath.Base(f.opts.Path)
+	ext = filepath.Ext(base)
+	return dir, strings.TrimSuffix(base, ext) + "-", ext
+}
+
+// runPostRotate compresses rotated files and removes the outdated ones each time the file is rotated.
+func (f *RotatingFile) runPostRotate() {
+	defer f.wg.Done()
+	for range f.postRotate {
+		// Errors are ignored: there is no place to report them, and the next rotation will retry.
+		_ = f.postRotateOnce()
+	}
+}
+
+// backupFile describes a rotated file.
+type backupFile struct {
+	path       string
+	rotatedAt  time.Time
+	seq        int // Sequence number of files rotated within the same millisecond.
+	compressed bool
+}
+
+// postRotateOnce compresses and removes rotated files according to the options.
+func (f *RotatingFile) postRotateOnce() error {
+	backups, err := f.listBackups()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	now := time.Now()
+	for i, b := range backups {
+		outdated := f.opts.MaxAge > 0 && now.Sub(b.rotatedAt) > f.opts.MaxAge
+		if (f.opts.MaxBackups > 0 && i >= f.opts.MaxBackups) || outdated {
+			errs = append(errs, os.Remove(b.path))
+			continue
+		}
+		if f.opts.Compress && !b.compressed {
+			errs = append(errs, compressFile(b.path))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// listBackups returns rotated files sorted from the newest to the oldest.
+func (f *RotatingFile) listBackups() ([]backupFile, error) {
+	dir, prefix, ext := f.nameParts()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]backupFile, 0, len(entries))
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		ts := strings.TrimPrefix(name, prefix)
+		compressed := strings.HasSuffix(ts, ext+".gz")
+		ts = strings.TrimSuffix(strings.TrimSuffix(ts, ".gz"), ext)
+		var seq int
+		if len(ts) > len(backupTimeFormat) && ts[len(backupTimeFormat)] == '.' {
+			if seq, err = strconv.Atoi(ts[len(backupTimeFormat)+1:]); err != nil || seq <= 0 {
+				continue
+			}
+			ts = ts[:len(backupTimeFormat)]
+		}
+		rotatedAt, err := time.ParseInLocation(backupTimeFormat, ts, time.Local)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, backupFile{filepath.Join(dir, name), rotatedAt, seq, compressed})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].rotatedAt.Equal(backups[j].rotatedAt) {
+			return backups[i].rotatedAt.After(backups[j].rotatedAt)
+		}
+		return backups[i].seq > backups[j].seq
+	})
+	return backups, nil
+}
+
+// compressFile compresses the file with gzip and removes the original one.
+func compressFile(path string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(path + ".gz")
+		}
+	}()
+
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+
+	_ = src.Close()
+	return os.Remove(path)
+}
+
+// nextRotationTime returns the time of the next time-based rotation or zero time if it is disabled.
+func nextRotationTime(now time.Time, rotation string) time.Time {
+	switch rotation {
+	case RotationHourly:
+		return time.Date(now.Year(), now.Month(), now.Day(), now.Hour()+1, 0, 0, 0, now.Location())
+	case RotationDaily:
+		return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	default:
+		return time.Time{}
+	}
+}
@@ -0,0 +1,206 @@
+package logkit_test
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestRotatingFile() {
+	s.Run("size rotation", func() {
+		dir := s.T().TempDir()
+		path := filepath.Join(dir, "logs", "app.log")
+		f, err := logger.NewRotatingFile(logger.FileOptions{Path: path, MaxSizeMB: 1, MaxBackups: 2})
+		s.Require().NoError(err, "got error, expected nil")
+
+		chunk := []byte(strings.Repeat("x", 512*1024-1) + "\n")
+		for range 7 {
+			_, err := f.Write(chunk)
+			s.Require().NoError(err, "got error, expected nil")
+		}
+		s.Require().NoError(f.Close(), "got error, expected nil")
+
+		entries, err := os.ReadDir(filepath.Join(dir, "logs"))
+		s.Require().NoError(err, "got error, expected nil")
+		// The active file and two most recent backups out of three rotated ones.
+		s.Require().Len(entries, 3, "unexpected amount of files")
+
+		info, err := os.Stat(path)
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().Equal(int64(len(chunk)), info.Size(), "unexpected size of the active file")
+
+		_, err = f.Write(chunk)
+		s.Require().ErrorIs(err, os.ErrClosed, "expected error on write after close")
+	})
+
+	s.Run("compression", func() {
+		dir := s.T().TempDir()
+		path := filepath.Join(dir, "app.log")
+		f, err := logger.NewRotatingFile(logger.FileOptions{Path: path, Compress: true})
+		s.Require().NoError(err, "got error, expected nil")
+
+		_, err = f.Write([]byte("rotated\n"))
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().NoError(f.Rotate(), "got error, expected nil")
+		s.Require().NoError(f.Close(), "got error, expected nil")
+
+		matches, err := filepath.Glob(filepath.Join(dir, "app-*.log.gz"))
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().Len(matches, 1, "unexpected amount of compressed files")
+
+		gzFile, err := os.Open(matches[0])
+		s.Require().NoError(err, "got error, expected nil")
+		defer gzFile.Close()
+		gz, err := gzip.NewReader(gzFile)
+		s.Require().NoError(err, "got error, expected nil")
+		data, err := io.ReadAll(gz)
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().Equal("rotated\n", string(data), "unexpected content of the compressed file")
+	})
+
+	s.Run("max age", func() {
+		dir := s.T().TempDir()
+		path := filepath.Join(dir, "app.log")
+		outdated := filepath.Join(dir, "app-"+time.Now().Add(-2*time.Hour).Format("2006-01-02T15-04-05.000")+".log")
+		s.Require().NoError(os.WriteFile(outdated, []byte("old\n"), 0o644), "got error, expected nil")
+
+		f, err := logger.NewRotatingFile(logger.FileOptions{Path: path, MaxAge: time.Hour})
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().NoError(f.Rotate(), "got error, expected nil")
+		s.Require().NoError(f.Close(), "got error, expected nil")
+
+		_, err = os.Stat(outdated)
+		s.Require().ErrorIs(err, os.ErrNotExist, "outdated file was not removed")
+	})
+
+	s.Run("rotations within a millisecond", func() {
+		for _, tC := range []struct {
+			maxBackups int
+			expected   []string
+		}{
+			{0, []string{"0\n", "1\n", "2\n", "3\n", "4\n"}},
+			{3, []string{"2\n", "3\n", "4\n"}},
+		} {
+			dir := s.T().TempDir()
+			opts := logger.FileOptions{Path: filepath.Join(dir, "app.log"), MaxBackups: tC.maxBackups}
+			f, err := logger.NewRotatingFile(opts)
+			s.Require().NoError(err, "got error, expected nil")
+			for i := range 5 {
+				_, err := f.Write([]byte(strconv.Itoa(i) + "\n"))
+				s.Require().NoError(err, "got error, expected nil")
+				s.Require().NoError(f.Rotate(), "got error, expected nil")
+			}
+			s.Require().NoError(f.Close(), "got error, expected nil")
+
+			matches, err := filepath.Glob(filepath.Join(dir, "app-*.log"))
+			s.Require().NoError(err, "got error, expected nil")
+			contents := make([]string, 0, len(matches))
+			for _, path := range matches {
+				data, err := os.ReadFile(path)
+				s.Require().NoError(err, "got error, expected nil")
+				contents = append(contents, string(data))
+			}
+			s.Require().ElementsMatch(tC.expected, contents, "rotated files must not overwrite each other")
+		}
+	})
+
+	s.Run("failed rotation", func() {
+		dir := s.T().TempDir()
+		logDir := filepath.Join(dir, "logs")
+		path := filepath.Join(logDir, "app.log")
+		f, err := logger.NewRotatingFile(logger.FileOptions{Path: path})
+		s.Require().NoError(err, "got error, expected nil")
+		defer f.Close()
+
+		// Replacing the directory with a regular file makes the rename fail.
+		s.Require().NoError(os.Rename(logDir, filepath.Join(dir, "moved")), "got error, expected nil")
+		s.Require().NoError(os.WriteFile(logDir, nil, 0o644), "got error, expected nil")
+		s.Require().Error(f.Rotate(), "got nil, expected error")
+		_, err = f.Write([]byte("lost\n"))
+		s.Require().Error(err, "got nil, expected error")
+
+		// The file is reopened once the cause is removed.
+		s.Require().NoError(os.Remove(logDir), "got error, expected nil")
+		_, err = f.Write([]byte("recovered\n"))
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().NoError(f.Rotate(), "got error, expected nil")
+		_, err = f.Write([]byte("rotated\n"))
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().NoError(f.Sync(), "got error, expected nil")
+
+		data, err := os.ReadFile(path)
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().Equal("rotated\n", string(data), "unexpected content of the active file")
+		matches, err := filepath.Glob(filepath.Join(logDir, "app-*.log"))
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().Len(matches, 1, "unexpected amount of rotated files")
+		data, err = os.ReadFile(matches[0])
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().Equal("recovered\n", string(data), "unexpected content of the rotated file")
+	})
+
+	s.Run("invalid options", func() {
+		for _, opts := range []logger.FileOptions{
+			{},
+			{Path: "app.log", MaxSizeMB: -1},
+			{Path: "app.log", Rotation: "weekly"},
+		} {
+			_, err := logger.NewRotatingFile(opts)
+			s.Require().Error(err, "got nil, expected error for %+v", opts)
+		}
+	})
+}
+
+func (s *LoggerTestSuite) TestFileConfig() {
+	s.Run("file stream", func() {
+		path := filepath.Join(s.T().TempDir(), "app.log")
+		l, err := logger.NewLogger(logger.WithConfig(map[string]any{
+			"level":       "info",
+			"log_stream":  "file",
+			"file_path":   path,
+			"max_size_mb": float64(10),
+			"max_backups": 3,
+			"max_age":     "24h",
+			"rotation":    "daily",
+			"compress":    true,
+		}))
+		s.Require().NoError(err, "got error, expected nil")
+
+		l.Info(context.Background(), "to file")
+		s.Require().NoError(l.Close(context.Background()), "got error, expected nil")
+
+		data, err := os.ReadFile(path)
+		s.Require().NoError(err, "got error, expected nil")
+		entry, err := decodeJSON(data)
+		s.Require().NoError(err, "failed to unmarshal log entry")
+		s.Require().Equal("to file", entry.Msg, "unexpected log message")
+	})
+
+	testCases := []struct {
+		name   string
+		config map[string]any
+	}{
+		{"missing path", map[string]any{"log_stream": "file"}},
+		{"empty path", map[string]any{"log_stream": "file", "file_path": ""}},
+		{"invalid size type", map[string]any{"max_size_mb": "10"}},
+		{"fractional size", map[string]any{"max_size_mb": 1.5}},
+		{"negative backups", map[string]any{"max_backups": -1}},
+		{"invalid age", map[string]any{"max_age": "week"}},
+		{"invalid rotation", map[string]any{"rotation": "weekly"}},
+		{"invalid compress type", map[string]any{"compress": "yes"}},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			_, err := logger.NewLogger(logger.WithConfig(tC.config))
+			s.Require().Error(err, "got nil, expected error")
+		})
+	}
+}
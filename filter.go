@@ -0,0 +1,93 @@
+package logkit
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+// recordFilter decides which records are written, e.g. sampler, deduplicator or rateLimiter.
+type recordFilter interface {
+	// allow reports whether the record must be written. withAttrs are the attributes added to the logger via With.
+	allow(r slog.Record, withAttrs []slog.Attr) bool
+}
+
+// filterHandler is a slog.Handler which passes the records allowed by the filter to the next handler.
+type filterHandler struct {
+	filter recordFilter
+	next   slog.Handler
+	attrs  []slog.Attr // Attributes added via WithAttrs.
+}
+
+// Enabled implements slog.Handler.
+func (h *filterHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *filterHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.filter.allow(r, h.attrs) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *filterHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &filterHandler{h.filter, h.next.WithAttrs(attrs), append(slices.Clip(h.attrs), attrs...)}
+}
+
+// WithGroup implements slog.Handler.
+func (h *filterHandler) WithGroup(name string) slog.Handler {
+	return &filterHandler{h.filter, h.next.WithGroup(name), h.attrs}
+}
+
+// reporter calls the report function of a filter every interval in background, and once more when it is closed.
+type reporter struct {
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// startReporter starts the report goroutine. final is true for the last report, written on close.
+func startReporter(interval time.Duration, report func(final bool)) *reporter {
+	r := &reporter{stop: make(chan struct{}), done: make(chan struct{})}
+	go r.run(interval, report)
+	return r
+}
+
+// run reports every interval until the reporter is stopped.
+func (r *reporter) run(interval time.Duration, report func(final bool)) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			report(false)
+		case <-r.stop:
+			report(true)
+			return
+		}
+	}
+}
+
+// close stops the reporter and waits for the last report. stopping is called once, before the last report starts,
+// so the filter can switch to passing records through: nothing would report the ones it suppresses after that.
+func (r *reporter) close(ctx context.Context, stopping func()) error {
+	r.once.Do(func() {
+		stopping()
+		close(r.stop)
+	})
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,191 @@
+package logkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxLevelRequestSize is a maximum size of the level request body.
+const maxLevelRequestSize = 4 << 10
+
+// levelRequest is a body of PUT/POST requests accepted by the level handler.
+type levelRequest struct {
+	Level string `json:"level"`
+	TTL   string `json:"ttl,omitempty"`
+}
+
+// levelResponse is a body of the level handler responses.
+type levelResponse struct {
+	Level    string `json:"level,omitempty"`
+	RevertAt string `json:"revert_at,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// levelHandler serves the current log level of a Logger and allows to change it.
+type levelHandler struct {
+	logg     *Logger
+	mu       sync.Mutex
+	timer    *time.Timer
+	timerGen uint64 // Guards against reverts by timers which fired while being replaced.
+	revertTo slog.Level
+	revertOK bool // Whether the component had its own level before the change, it is removed on revert otherwise.
+	revertAt time.Time
+}
+
+// NewLevelHandler returns an http.Handler which allows to view and change the log level of the logger.
+// The change affects the logger the same way as Logger.SetLevel does.
+//
+// Supported methods:
+//   - GET: returns the current level, e.g. {"level":"INFO"}.
+//   - PUT, POST: sets a new level. Expected body is {"level":"debug","ttl":"10m"}. Bodies with unknown fields,
+//     trailing data or larger than 4 KB are rejected.
+//
+// The level is validated the same way as the "level" config field. ttl is optional and accepts any value
+// valid for time.ParseDuration. If ttl is set, the level reverts to the one active before the change
+// once ttl expires, unless the level was changed again in the meantime. A named logger without its own level
+// inherits the level of its parent again after the revert.
+//
+// Example:
+//
+//	mux.Handle("/debug/loglevel", logkit.NewLevelHandler(logger))
+func NewLevelHandler(logg *Logger) http.Handler {
+	return &levelHandler{logg: logg}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.writeResponse(w, http.StatusOK, h.state())
+	case http.MethodPut, http.MethodPost:
+		req, err := decodeLevelRequest(w, r)
+		if err != nil {
+			code := http.StatusBadRequest
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				code = http.StatusRequestEntityTooLarge
+			}
+			h.writeResponse(w, code, levelResponse{Error: "invalid request body: " + err.Error()})
+			return
+		}
+
+		ttl, err := parseLevelRequest(req)
+		if err != nil {
+			h.writeResponse(w, http.StatusBadRequest, levelResponse{Error: err.Error()})
+			return
+		}
+
+		h.setLevel(levelValues[strings.ToLower(req.Level)], ttl)
+		h.writeResponse(w, http.StatusOK, h.state())
+	default:
+		w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPut, http.MethodPost}, ", "))
+		h.writeResponse(w, http.StatusMethodNotAllowed, levelResponse{Error: "method not allowed"})
+	}
+}
+
+// decodeLevelRequest decodes the request body, rejecting oversized bodies, unknown fields and trailing data.
+func decodeLevelRequest(w http.ResponseWriter, r *http.Request) (levelRequest, error) {
+	var req levelRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxLevelRequestSize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return req, err
+	}
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return req, errors.New("unexpected data after the request")
+	}
+	return req, nil
+}
+
+// errInvalidTTL is a problem of non-positive or malformed TTLs of level requests.
+var errInvalidTTL = errors.New("invalid ttl")
+
+// parseLevelRequest validates the request and returns the parsed TTL.
+func parseLevelRequest(req levelRequest) (time.Duration, error) {
+	ve := &ConfigError{}
+	validateLogLevel(map[string]any{"level": req.Level}, ve)
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		var err error
+		ttl, err = time.ParseDuration(req.TTL)
+		if err != nil || ttl <= 0 {
+			ve.addInvalidValue("ttl", req.TTL, errInvalidTTL)
+		}
+	}
+
+	if ve.hasErrors() {
+		return 0, fmt.Errorf("request data is invalid: %w", ve)
+	}
+	return ttl, nil
+}
+
+// setLevel sets the new level and schedules the revert if ttl is positive.
+// Any previously scheduled revert is cancelled.
+func (h *levelHandler) setLevel(level slog.Level, ttl time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Keeping the original level if the temporary level is being replaced by another temporary one.
+	revertTo, revertOK := h.logg.levels.override(h.logg.name)
+	if h.timer != nil {
+		h.timer.Stop()
+		h.timer = nil
+		h.timerGen++
+		revertTo, revertOK = h.revertTo, h.revertOK
+	}
+	h.revertAt = time.Time{}
+
+	h.logg.levels.setLevel(h.logg.name, level)
+	if ttl <= 0 {
+		return
+	}
+
+	h.timerGen++
+	gen := h.timerGen
+	h.revertTo, h.revertOK = revertTo, revertOK
+	h.revertAt = time.Now().Add(ttl)
+	h.timer = time.AfterFunc(ttl, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if gen != h.timerGen {
+			return
+		}
+		// The level might have been changed via Logger.SetLevel in the meantime: leaving it as is.
+		if h.logg.levels.level(h.logg.name) == level {
+			if h.revertOK {
+				h.logg.levels.setLevel(h.logg.name, h.revertTo)
+			} else {
+				h.logg.levels.unset(h.logg.name)
+			}
+		}
+		h.timer = nil
+		h.revertAt = time.Time{}
+	})
+}
+
+// state returns the current handler state.
+func (h *levelHandler) state() levelResponse {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	resp := levelResponse{Level: h.logg.Level()}
+	if !h.revertAt.IsZero() {
+		resp.RevertAt = h.revertAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// writeResponse writes JSON-encoded response with the given status code.
+func (h *levelHandler) writeResponse(w http.ResponseWriter, code int, resp levelResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,99 @@
+package logkit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestLevelHandler() {
+	l, err := logger.NewLogger(
+		logger.WithConfig(map[string]any{"level": "info"}),
+		logger.WithWriter(s.writer),
+	)
+	s.Require().NoError(err, "got error, expected nil")
+	handler := logger.NewLevelHandler(l)
+
+	do := func(method, body string) (int, map[string]string) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/loglevel", strings.NewReader(body)))
+		var resp map[string]string
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &resp), "failed to unmarshal response")
+		return rec.Code, resp
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		body          string
+		expectedCode  int
+		expectedLevel string
+	}{
+		{"get", http.MethodGet, "", http.StatusOK, "INFO"},
+		{"put", http.MethodPut, `{"level":"debug"}`, http.StatusOK, "DEBUG"},
+		{"post", http.MethodPost, `{"level":"TRACE"}`, http.StatusOK, "TRACE"},
+		{"unknown level", http.MethodPut, `{"level":"unknown"}`, http.StatusBadRequest, "TRACE"},
+		{"empty level", http.MethodPut, `{}`, http.StatusBadRequest, "TRACE"},
+		{"invalid ttl", http.MethodPut, `{"level":"info","ttl":"soon"}`, http.StatusBadRequest, "TRACE"},
+		{"invalid body", http.MethodPut, `level=info`, http.StatusBadRequest, "TRACE"},
+		{"unknown field", http.MethodPut, `{"level":"info","lvl":"info"}`, http.StatusBadRequest, "TRACE"},
+		{"trailing data", http.MethodPut, `{"level":"info"}{"level":"info"}`, http.StatusBadRequest, "TRACE"},
+		{
+			"oversized body", http.MethodPut, `{"level":"info","ttl":"` + strings.Repeat("1", 8<<10) + `s"}`,
+			http.StatusRequestEntityTooLarge, "TRACE",
+		},
+		{"unsupported method", http.MethodDelete, "", http.StatusMethodNotAllowed, "TRACE"},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			code, resp := do(tC.method, tC.body)
+			s.Require().Equal(tC.expectedCode, code, "unexpected status code")
+			if code == http.StatusOK {
+				s.Require().Equal(tC.expectedLevel, resp["level"], "unexpected level in response")
+			} else {
+				s.Require().NotEmpty(resp["error"], "expected error description")
+			}
+			s.Require().Equal(tC.expectedLevel, l.Level(), "unexpected logger level")
+		})
+	}
+
+	s.Run("ttl", func() {
+		s.Require().NoError(l.SetLevel("info"), "got error, expected nil")
+
+		code, resp := do(http.MethodPut, `{"level":"debug","ttl":"50ms"}`)
+		s.Require().Equal(http.StatusOK, code, "unexpected status code")
+		s.Require().NotEmpty(resp["revert_at"], "expected revert time in response")
+
+		// Replacing the temporary level with another temporary one keeps the original level to revert to.
+		code, _ = do(http.MethodPut, `{"level":"trace","ttl":"50ms"}`)
+		s.Require().Equal(http.StatusOK, code, "unexpected status code")
+		s.Require().Equal("TRACE", l.Level(), "unexpected logger level")
+
+		s.Require().Eventually(func() bool { return l.Level() == "INFO" }, time.Second, 10*time.Millisecond,
+			"level was not reverted")
+		_, resp = do(http.MethodGet, "")
+		s.Require().Empty(resp["revert_at"], "unexpected revert time in response")
+	})
+
+	s.Run("ttl of component", func() {
+		s.Require().NoError(l.SetLevel("info"), "got error, expected nil")
+		db := l.Named("db")
+		rec := httptest.NewRecorder()
+		logger.NewLevelHandler(db).ServeHTTP(rec,
+			httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"debug","ttl":"50ms"}`)))
+		s.Require().Equal(http.StatusOK, rec.Code, "unexpected status code")
+		s.Require().Equal("DEBUG", db.Level(), "unexpected component level")
+
+		// The component inherits the level again after the revert.
+		s.Require().Eventually(func() bool { return db.Level() == "INFO" }, time.Second, 10*time.Millisecond,
+			"level was not reverted")
+		s.Require().NotContains(l.Settings().Levels, "db", "unexpected component level override")
+		s.Require().NoError(l.SetLevel("trace"), "got error, expected nil")
+		s.Require().Equal("TRACE", db.Level(), "unexpected component level")
+	})
+}
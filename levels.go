@@ -0,0 +1,113 @@
+package logkit
+
+import (
+	"log/slog"
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// levelRegistry holds the base log level and per-component level overrides.
+// A single registry is shared by the logger and all loggers derived from it via With and Named.
+//
+// Level lookups are lock-free, as they happen on every logging call: overrides are stored in an immutable map
+// which is replaced on every change.
+type levelRegistry struct {
+	base      slog.LevelVar
+	overrides atomic.Pointer[map[string]slog.Level]
+	mu        sync.Mutex // Serializes the changes of overrides.
+}
+
+// newLevelRegistry returns a new registry with the given base level and component overrides.
+func newLevelRegistry(base slog.Level, overrides map[string]slog.Level) *levelRegistry {
+	r := &levelRegistry{}
+	r.base.Set(base)
+	m := maps.Clone(overrides)
+	if m == nil {
+		m = make(map[string]slog.Level)
+	}
+	r.overrides.Store(&m)
+	return r
+}
+
+// level returns the effective level for the component with the given dotted name.
+// The closest configured ancestor wins: for "http.client.retry" the lookup order is
+// "http.client.retry", "http.client", "http" and then the base level.
+func (r *levelRegistry) level(name string) slog.Level {
+	overrides := *r.overrides.Load()
+	for name != "" && len(overrides) > 0 {
+		if level, ok := overrides[name]; ok {
+			return level
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+
+	return r.base.Level()
+}
+
+// setLevel sets the level for the component with the given name.
+// Empty name corresponds to the base level.
+func (r *levelRegistry) setLevel(name string, level slog.Level) {
+	if name == "" {
+		r.base.Set(level)
+		return
+	}
+	r.update(func(m map[string]slog.Level) { m[name] = level })
+}
+
+// override returns the level override of the component with the given name, not taking its ancestors into account.
+// Empty name corresponds to the base level, which is always set.
+func (r *levelRegistry) override(name string) (slog.Level, bool) {
+	if name == "" {
+		return r.base.Level(), true
+	}
+	level, ok := (*r.overrides.Load())[name]
+	return level, ok
+}
+
+// unset removes the level override of the component with the given name, so it inherits the level again.
+// The base level can not be removed, so empty name is ignored.
+func (r *levelRegistry) unset(name string) {
+	r.update(func(m map[string]slog.Level) { delete(m, name) })
+}
+
+// update replaces the overrides with a modified copy.
+func (r *levelRegistry) update(modify func(map[string]slog.Level)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m := maps.Clone(*r.overrides.Load())
+	modify(m)
+	r.overrides.Store(&m)
+}
+
+// components returns a copy of the component level overrides.
+func (r *levelRegistry) components() map[string]slog.Level {
+	return maps.Clone(*r.overrides.Load())
+}
+
+// toStringMap converts a map received in configuration to map[string]string.
+// Returns false if the value is not a map with string keys or any of its values is not a string.
+func toStringMap(v any) (map[string]string, bool) {
+	switch m := v.(type) {
+	case map[string]string:
+		return m, true
+	case map[string]any:
+		res := make(map[string]string, len(m))
+		for k, val := range m {
+			s, ok := val.(string)
+			if !ok {
+				return nil, false
+			}
+			res[k] = s
+		}
+		return res, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,246 @@
+package logkit
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// logfmtHandler is a slog.Handler which writes records in logfmt format: a sequence of key=value pairs
+// separated by spaces, one record per line. Groups are flattened using dotted keys, e.g. "request.id=1".
+//
+// Values are quoted if they are empty or contain spaces, '=', '"', control or invalid UTF-8 characters.
+// Quoted values are escaped the same way as Go string literals. Invalid characters in keys are replaced with '_'.
+type logfmtHandler struct {
+	opts         slog.HandlerOptions
+	mu           *sync.Mutex
+	w            io.Writer
+	preformatted []byte   // Attributes added via WithAttrs.
+	prefix       string   // Dotted prefix of the open groups.
+	groups       []string // Open groups, passed to ReplaceAttr.
+}
+
+// newLogfmtHandler returns a new logfmt handler writing to w.
+func newLogfmtHandler(w io.Writer, opts *slog.HandlerOptions) *logfmtHandler {
+	h := &logfmtHandler{mu: &sync.Mutex{}, w: w}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+// Enabled implements slog.Handler.
+func (h *logfmtHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+// Handle implements slog.Handler.
+func (h *logfmtHandler) Handle(_ context.Context, r slog.Record) error {
+	buf := make([]byte, 0, 1024)
+
+	if !r.Time.IsZero() {
+		buf = h.appendAttr(buf, "", nil, slog.Time(slog.TimeKey, r.Time))
+	}
+	buf = h.appendAttr(buf, "", nil, slog.Any(slog.LevelKey, r.Level))
+	if h.opts.AddSource && r.PC != 0 {
+		buf = h.appendAttr(buf, "", nil, slog.Any(slog.SourceKey, recordSource(r)))
+	}
+	buf = h.appendAttr(buf, "", nil, slog.String(slog.MessageKey, r.Message))
+
+	if len(h.preformatted) > 0 {
+		buf = appendSeparator(buf)
+		buf = append(buf, h.preformatted...)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		buf = h.appendAttr(buf, h.prefix, h.groups, a)
+		return true
+	})
+	buf = append(buf, '\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf)
+	return err
+}
+
+// WithAttrs implements slog.Handler.
+func (h *logfmtHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.preformatted = slices.Clip(h.preformatted)
+	for _, a := range attrs {
+		h2.preformatted = h.appendAttr(h2.preformatted, h.prefix, h.groups, a)
+	}
+	return &h2
+}
+
+// WithGroup implements slog.Handler.
+func (h *logfmtHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	h2.groups = append(slices.Clip(h.groups), name)
+	return &h2
+}
+
+// appendAttr appends the attribute to buf. Groups are flattened, their keys are joined with dots.
+func (h *logfmtHandler) appendAttr(buf []byte, prefix string, groups []string, a slog.Attr) []byte {
+	flattenAttr(h.opts.ReplaceAttr, prefix, groups, a, func(key string, v slog.Value) {
+		buf = appendSeparator(buf)
+		buf = appendLogfmtKey(buf, key)
+		buf = append(buf, '=')
+		buf = appendLogfmtValue(buf, v)
+	})
+	return buf
+}
+
+// flattenAttr resolves the attribute, applies replace function to it and calls fn for the result.
+// Groups are flattened: fn is called for each of their non-group attributes with keys joined with dots.
+// Empty attributes and groups are skipped, groups with empty keys are inlined, as slog does.
+func flattenAttr(
+	replace func([]string, slog.Attr) slog.Attr,
+	prefix string,
+	groups []string,
+	a slog.Attr,
+	fn func(key string, v slog.Value),
+) {
+	a.Value = a.Value.Resolve()
+	if replace != nil && a.Value.Kind() != slog.KindGroup {
+		a = replace(groups, a)
+		a.Value = a.Value.Resolve()
+	}
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+			groups = append(slices.Clip(groups), a.Key)
+		}
+		for _, ga := range a.Value.Group() {
+			flattenAttr(replace, prefix, groups, ga, fn)
+		}
+		return
+	}
+
+	fn(prefix+a.Key, a.Value)
+}
+
+// appendSeparator appends a space to non-empty buf.
+func appendSeparator(buf []byte) []byte {
+	if len(buf) > 0 {
+		return append(buf, ' ')
+	}
+	return buf
+}
+
+// appendLogfmtKey appends the key replacing characters which are not allowed in logfmt keys with '_'.
+func appendLogfmtKey(buf []byte, key string) []byte {
+	if key == "" {
+		return append(buf, '_')
+	}
+	for _, r := range key {
+		if r == '=' || r == '"' || r == utf8.RuneError || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			r = '_'
+		}
+		buf = utf8.AppendRune(buf, r)
+	}
+	return buf
+}
+
+// appendLogfmtValue appends the value, quoting it if necessary.
+func appendLogfmtValue(buf []byte, v slog.Value) []byte {
+	switch v.Kind() {
+	case slog.KindString:
+		return appendLogfmtString(buf, v.String())
+	case slog.KindInt64:
+		return strconv.AppendInt(buf, v.Int64(), 10)
+	case slog.KindUint64:
+		return strconv.AppendUint(buf, v.Uint64(), 10)
+	case slog.KindFloat64:
+		return strconv.AppendFloat(buf, v.Float64(), 'g', -1, 64)
+	case slog.KindBool:
+		return strconv.AppendBool(buf, v.Bool())
+	case slog.KindDuration:
+		return appendLogfmtString(buf, v.Duration().String())
+	case slog.KindTime:
+		return appendLogfmtString(buf, v.Time().Format(time.RFC3339Nano))
+	default:
+		return appendLogfmtString(buf, anyToString(v.Any()))
+	}
+}
+
+// anyToString returns a string representation of an arbitrary value.
+func anyToString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "<nil>"
+	case errorValue:
+		return v.String()
+	case error:
+		if isNilError(v) {
+			return "<nil>"
+		}
+		return errorMessage(v)
+	case *slog.Source:
+		return fmt.Sprintf("%s:%d", v.File, v.Line)
+	case encoding.TextMarshaler:
+		data, err := v.MarshalText()
+		if err != nil {
+			return "!ERROR:" + err.Error()
+		}
+		return string(data)
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprintf("%+v", v)
+	}
+}
+
+// appendLogfmtString appends the string, quoting it if necessary.
+func appendLogfmtString(buf []byte, s string) []byte {
+	if needsQuoting(s) {
+		return strconv.AppendQuote(buf, s)
+	}
+	return append(buf, s...)
+}
+
+// needsQuoting returns true if the string must be quoted to be a valid logfmt value.
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordSource returns the source location of the record.
+func recordSource(r slog.Record) *slog.Source {
+	frames := runtime.CallersFrames([]uintptr{r.PC})
+	f, _ := frames.Next()
+	return &slog.Source{Function: f.Function, File: f.File, Line: f.Line}
+}
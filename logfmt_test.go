@@ -0,0 +1,93 @@
+package logkit_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestLogfmt() {
+	testCases := []struct {
+		name     string
+		with     []any
+		group    string
+		args     []any
+		msg      string
+		expected string
+	}{
+		{
+			name:     "plain values",
+			msg:      "done",
+			args:     []any{"count", 3, "ratio", 0.5, "ok", true, "elapsed", time.Second, "user", "bob"},
+			expected: `level=INFO msg=done count=3 ratio=0.5 ok=true elapsed=1s user=bob`,
+		},
+		{
+			name:     "quoting",
+			msg:      "request failed",
+			args:     []any{"empty", "", "eq", "a=b", "quote", `say "hi"`, "newline", "a\nb", "err", errors.New("boom")},
+			expected: `level=INFO msg="request failed" empty="" eq="a=b" quote="say \"hi\"" newline="a\nb" err.msg=boom err.type=*errors.errorString`,
+		},
+		{
+			name:     "invalid key characters",
+			msg:      "keys",
+			args:     []any{"bad key", 1, "a=b", 2},
+			expected: `level=INFO msg=keys bad_key=1 a_b=2`,
+		},
+		{
+			name:     "groups",
+			msg:      "grouped",
+			with:     []any{"service", "auth"},
+			group:    "request",
+			args:     []any{"id", 1, slog.Group("user", "name", "bob"), slog.Group("", "inline", 1)},
+			expected: `level=INFO msg=grouped service=auth request.id=1 request.user.name=bob request.inline=1`,
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			s.writer.CleanUp()
+			l, err := logger.NewLogger(
+				logger.WithConfig(map[string]any{"format": "logfmt", "level": "info", "time_template": time.RFC3339}),
+				logger.WithWriter(s.writer),
+			)
+			s.Require().NoError(err, "got error, expected nil")
+
+			if len(tC.with) > 0 {
+				l = l.With(tC.with...)
+			}
+			if tC.group != "" {
+				l = l.With(slog.Group(tC.group, tC.args...))
+				tC.args = nil
+			}
+			l.Info(context.Background(), tC.msg, tC.args...)
+			s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+
+			line := string(s.writer.arr[0])
+			s.Require().True(strings.HasSuffix(line, "\n"), "record is not terminated with a newline")
+			timePart, rest, found := strings.Cut(strings.TrimSuffix(line, "\n"), " ")
+			s.Require().True(found, "unexpected record format")
+			s.Require().True(strings.HasPrefix(timePart, "time="), "unexpected first key")
+			_, err = time.Parse(time.RFC3339, strings.TrimPrefix(timePart, "time="))
+			s.Require().NoError(err, "unexpected time format")
+			s.Require().Equal(tC.expected, rest, "unexpected record")
+		})
+	}
+
+	s.Run("custom level and quoted time", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"format": "logfmt", "level": "trace"}),
+			logger.WithWriter(s.writer),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		l.Trace(context.Background(), "trace")
+		s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+		s.Require().Regexp(`^time="\d{2}\.\d{2}\.\d{4} \d{2}:\d{2}:\d{2}\.\d{3}" level=TRACE msg=trace\n$`,
+			string(s.writer.arr[0]), "unexpected record")
+	})
+}
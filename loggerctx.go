@@ -0,0 +1,92 @@
+package logkit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// loggerCtxKey is the context key of the logger stored via NewContext.
+type loggerCtxKey struct{}
+
+var (
+	defaultLogger     atomic.Pointer[Logger]
+	defaultLoggerOnce sync.Once
+)
+
+// NewContext returns a copy of ctx carrying the logger, see FromContext.
+//
+// Middleware can attach a request-scoped logger once, so deeper code picks it up from the context:
+//
+//	ctx = logkit.NewContext(ctx, logger.With("request_id", id))
+//	// In a deeper layer:
+//	logkit.Info(ctx, "order created")
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// FromContext returns the logger stored in ctx via NewContext. If there is none, the default logger is returned,
+// see SetDefault.
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerCtxKey{}).(*Logger); ok && l != nil {
+		return l
+	}
+	return Default()
+}
+
+// Default returns the default logger, used by FromContext if no logger is stored in the context.
+// Unless replaced via SetDefault, it is the logger created by NewLogger without options.
+func Default() *Logger {
+	defaultLoggerOnce.Do(func() {
+		// Initialization with defaults only can not fail.
+		l, _ := NewLogger()
+		defaultLogger.CompareAndSwap(nil, l)
+	})
+	return defaultLogger.Load()
+}
+
+// SetDefault replaces the default logger. Nil logger is ignored.
+func SetDefault(l *Logger) {
+	if l != nil {
+		defaultLogger.Store(l)
+	}
+}
+
+// Package-level logging functions call Logger.log directly, so the caller is captured correctly.
+
+// Trace logs a message with level Trace using the logger from ctx, see FromContext.
+func Trace(ctx context.Context, msg string, args ...any) {
+	FromContext(ctx).log(ctx, LevelTrace, msg, args...)
+}
+
+// Debug logs a message with level Debug using the logger from ctx, see FromContext.
+func Debug(ctx context.Context, msg string, args ...any) {
+	FromContext(ctx).log(ctx, LevelDebug, msg, args...)
+}
+
+// Verbose logs a message with level Verbose using the logger from ctx, see FromContext.
+func Verbose(ctx context.Context, msg string, args ...any) {
+	FromContext(ctx).log(ctx, LevelVerbose, msg, args...)
+}
+
+// Info logs a message with level Info using the logger from ctx, see FromContext.
+func Info(ctx context.Context, msg string, args ...any) {
+	FromContext(ctx).log(ctx, LevelInfo, msg, args...)
+}
+
+// Warn logs a message with level Warn using the logger from ctx, see FromContext.
+func Warn(ctx context.Context, msg string, args ...any) {
+	FromContext(ctx).log(ctx, LevelWarn, msg, args...)
+}
+
+// Error logs a message with level Error using the logger from ctx, see FromContext.
+func Error(ctx context.Context, msg string, args ...any) {
+	FromContext(ctx).log(ctx, LevelError, msg, args...)
+}
+
+// Fatal logs a message with level Fatal using the logger from ctx and then exits, see Logger.Fatal.
+func Fatal(ctx context.Context, msg string, args ...any) {
+	l := FromContext(ctx)
+	l.log(ctx, LevelFatal, msg, args...)
+	l.exit(ctx)
+}
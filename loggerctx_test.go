@@ -0,0 +1,72 @@
+package logkit_test
+
+import (
+	"context"
+	"encoding/json"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestLoggerContext() {
+	s.Run("context logger", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info", "add_source": true, "source_path": "file"}),
+			logger.WithWriter(s.writer),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		ctx := logger.NewContext(context.Background(), l.With("request_id", "abc-123"))
+		s.Require().NotNil(logger.FromContext(ctx), "got nil, expected logger")
+
+		logger.Debug(ctx, "filtered")
+		logger.Info(ctx, "handled")
+		line := currentLine() - 1
+		logger.Warn(ctx, "slow")
+		s.Require().Len(s.writer.arr, 2, "unexpected amount of logs received")
+
+		var entry struct {
+			sourceEntry
+			Msg       string `json:"msg"`
+			RequestID string `json:"request_id"`
+		}
+		s.Require().NoError(json.Unmarshal(s.writer.arr[0], &entry), "failed to unmarshal log entry")
+		s.Require().Equal("handled", entry.Msg, "unexpected log message")
+		s.Require().Equal("abc-123", entry.RequestID, "request-scoped attributes must be added")
+		s.Require().Equal("loggerctx_test.go", entry.Source.File, "unexpected source file")
+		s.Require().Equal(line, entry.Source.Line, "caller must be captured")
+	})
+
+	s.Run("default logger", func() {
+		s.writer.CleanUp()
+		prev := logger.Default()
+		s.Require().NotNil(prev, "got nil, expected default logger")
+		defer logger.SetDefault(prev)
+
+		l, err := logger.NewLogger(logger.WithConfig(map[string]any{"level": "info"}), logger.WithWriter(s.writer))
+		s.Require().NoError(err, "got error, expected nil")
+		logger.SetDefault(l)
+		logger.SetDefault(nil)
+
+		s.Require().Same(l, logger.FromContext(context.Background()), "default logger must be returned")
+		s.Require().Same(l, logger.FromContext(logger.NewContext(context.Background(), nil)),
+			"default logger must be returned")
+
+		logger.Error(context.Background(), "failed")
+		s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+	})
+
+	s.Run("fatal", func() {
+		s.writer.CleanUp()
+		exitCode := -1
+		l, err := logger.NewLogger(
+			logger.WithWriter(s.writer),
+			logger.WithExitFunc(func(code int) { exitCode = code }),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		logger.Fatal(logger.NewContext(context.Background(), l), "fatal")
+		s.Require().Equal(1, exitCode, "unexpected exit code")
+		s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+	})
+}
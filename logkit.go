@@ -9,6 +9,8 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 // Option defines a function that allows to configure underlying logger on construction.
@@ -16,77 +18,182 @@ type Option func(c *Config) error
 
 // Config defines an inner logger configuration.
 type Config struct {
-	handlerOpts    *slog.HandlerOptions
-	logType        string
-	handler        slog.Handler
-	writer         io.Writer
-	timeTemplate   string
-	level          slog.Level
-	setupLevel     bool
-	extraCtxFields []any
+	handlerOpts     *slog.HandlerOptions
+	logType         string
+	handler         slog.Handler
+	writer          io.Writer
+	ownedWriter     io.Closer // Writer opened by the logger itself, e.g. a log file. Closed on Logger.Close.
+	timeTemplate    string
+	level           slog.Level
+	componentLevels map[string]slog.Level
+	setupLevel      bool
+	extraCtxFields  []any
+	async           *asyncConfig
+	fatal           fatalConfig
+	addSource       bool
+	sourcePath      string
+	callerSkip      int
+	stacktrace      bool
+	stacktraceLevel slog.Level
+	watch           *watchConfig
+	traceKeys       *traceKeys
+	spanEvents      bool
+	spanEventsLevel slog.Level
+	logStream       string      // Name of the log stream, empty for custom writers.
+	fileOpts        FileOptions // Options of the log file, applied for "file" log stream.
+	otlp            []OTLPOptions
+	sampling        *samplingConfig
+	dedup           dedupConfig
+	rateLimits      *rateLimitConfig
 }
 
 // WithConfig allows to apply custom configuration.
 // Expected following config structure:
 //
 //	{
-//			format        string, // "text" or "json"
+//			format        string, // "text", "json", "logfmt" or "console"
 //			level         string, // "debug", "info", "warn", "error"
 //			time_template string, // any valid time format
-//			log_stream:   string, // "stdout", "stderr"
+//			log_stream:   string, // "stdout", "stderr", "file"
+//			levels        map[string]string, // component name -> level, e.g. {"db": "trace", "http.client": "warn"}
+//			file_path     string, // path to the log file, required for "file" log stream
+//			max_size_mb   int,    // maximum size of the log file before rotation, 0 - unlimited
+//			max_age       string, // maximum age of rotated files, e.g. "168h", 0 - unlimited
+//			max_backups   int,    // maximum number of rotated files to keep, 0 - unlimited
+//			rotation      string, // time-based rotation: "hourly", "daily" or empty
+//			compress      bool,   // gzip compression of rotated files
+//			add_source    bool,   // capture the source location of logging calls
+//			source_path   string, // source file path form: "full", "module" or "file"
+//			stacktrace_level string, // minimal level of records with stack traces, empty - disabled
+//			dedup_window  string, // window of duplicate records suppression, e.g. "10s", empty or 0 - disabled
+//			dedup_attrs   string, // comma-separated keys of attributes identifying duplicates, e.g. "tenant_id"
 //	}
+//
+// Component levels are applied to named loggers, see Logger.Named.
+// File options are applied only for "file" log stream, see RotatingFile. The file is closed on Logger.Close.
+// Source options are described in WithSource and SourcePathFull, SourcePathModule, SourcePathFile constants.
+// Stack traces are described in WithStacktrace.
+//
+// Deduplication collapses records with the same level, message, logger name and values of dedup_attrs attributes:
+// after a record is written, its repeats are suppressed while they keep coming within dedup_window of each other.
+// Once per window, the suppressed repeats are summarized by a record with the same level, message, logger name
+// and dedup_attrs attributes, holding the amount under RepeatCountKey and the times of the first and last repeats
+// under FirstSeenKey and LastSeenKey. Pending summaries are written on Logger.Close, and repeats
+// are not suppressed after that.
 func WithConfig(cfg map[string]any) Option {
 	return func(c *Config) error {
-		optionalFields := map[string]any{
-			"format":        "",
-			"level":         "",
-			"time_template": "",
-			"log_stream":    "",
-		}
+		return c.applyConfig(cfg, &ConfigError{})
+	}
+}
 
-		ve := &validationError{}
-		ve.invalidTypes = validateTypes(cfg, optionalFields)
+// configFields lists the supported config fields with values of their expected types.
+var configFields = map[string]any{
+	"format":           "",
+	"level":            "",
+	"time_template":    "",
+	"log_stream":       "",
+	"levels":           map[string]any{},
+	"file_path":        "",
+	"max_size_mb":      0,
+	"max_age":          "",
+	"max_backups":      0,
+	"rotation":         "",
+	"compress":         false,
+	"add_source":       false,
+	"source_path":      "",
+	"stacktrace_level": "",
+	"dedup_window":     "",
+	"dedup_attrs":      "",
+}
 
-		validateLogLevel(cfg, ve)
-		validateTimeFormat(cfg, ve)
-		validateWriter(cfg, ve)
-		validateLogType(cfg, ve)
+// applyConfig validates the config and applies it. Errors already collected in ve are reported along with
+// the validation ones.
+func (c *Config) applyConfig(cfg map[string]any, ve *ConfigError) error {
+	validateTypes(cfg, configFields, ve)
 
-		if ve.hasErrors() {
-			return fmt.Errorf("config data is invalid: %s", ve.Error())
-		}
+	validateLogLevel(cfg, ve)
+	validateComponentLevels(cfg, ve)
+	validateTimeFormat(cfg, ve)
+	validateWriter(cfg, ve)
+	validateFileOptions(cfg, ve)
+	validateLogType(cfg, ve)
+	validateSourcePath(cfg, ve)
+	validateStacktraceLevel(cfg, ve)
+	validateDedupOptions(cfg, ve)
 
-		if level, ok := cfg["level"]; ok {
-			levelStr := strings.ToLower(level.(string))
-			if level, ok := levelValues[levelStr]; ok {
-				c.level = level
-			} else {
-				c.setupLevel = true
-			}
+	if ve.hasErrors() {
+		return fmt.Errorf("config data is invalid: %w", ve)
+	}
+
+	if level, ok := cfg["level"]; ok {
+		levelStr := strings.ToLower(level.(string))
+		if level, ok := levelValues[levelStr]; ok {
+			c.level = level
+		} else {
+			c.setupLevel = true
 		}
+	}
 
-		if timeTmpl, ok := cfg["time_template"]; ok {
-			c.timeTemplate = timeTmpl.(string)
+	if levels, ok := cfg["levels"]; ok {
+		levelsMap, _ := toStringMap(levels)
+		if c.componentLevels == nil {
+			c.componentLevels = make(map[string]slog.Level, len(levelsMap))
 		}
+		for name, level := range levelsMap {
+			c.componentLevels[name] = levelValues[strings.ToLower(level)]
+		}
+	}
+
+	if timeTmpl, ok := cfg["time_template"]; ok {
+		c.timeTemplate = timeTmpl.(string)
+	}
 
-		if writer, ok := cfg["log_stream"]; ok {
-			switch strings.ToLower(writer.(string)) {
-			case "stdout":
-				c.writer = os.Stdout
-			case "stderr":
-				c.writer = os.Stderr
+	if writer, ok := cfg["log_stream"]; ok {
+		stream := strings.ToLower(writer.(string))
+		switch stream {
+		case "stdout":
+			c.setWriter(os.Stdout, nil)
+		case "stderr":
+			c.setWriter(os.Stderr, nil)
+		case "file":
+			c.fileOpts = fileOptions(cfg)
+			f, err := NewRotatingFile(c.fileOpts)
+			if err != nil {
+				return fmt.Errorf("log file initialization failed: %w", err)
 			}
+			c.setWriter(f, f)
 		}
+		c.logStream = stream
+	}
 
-		if logType, ok := cfg["format"]; ok {
-			c.logType = logType.(string)
-		}
+	if logType, ok := cfg["format"]; ok {
+		c.logType = logType.(string)
+	}
 
-		c.checkDefaults()
-		c.handler = buildHandler(c)
+	if addSource, ok := cfg["add_source"]; ok {
+		c.addSource = addSource.(bool)
+	}
 
-		return nil
+	if sourcePath, ok := cfg["source_path"]; ok {
+		c.sourcePath = strings.ToLower(sourcePath.(string))
+	}
+
+	if stacktraceLevel, ok := cfg["stacktrace_level"]; ok {
+		c.stacktraceLevel, c.stacktrace = levelValues[strings.ToLower(stacktraceLevel.(string))]
+	}
+
+	if window, ok := cfg["dedup_window"]; ok {
+		c.dedup.window, _ = time.ParseDuration(window.(string))
 	}
+
+	if attrs, ok := cfg["dedup_attrs"]; ok {
+		c.dedup.attrs = dedupAttrs(attrs.(string))
+	}
+
+	c.checkDefaults()
+	c.handler = buildHandler(c)
+
+	return nil
 }
 
 // WithWriter allows to apply custom configuration.
@@ -96,7 +203,8 @@ func WithWriter(w io.Writer) Option {
 			return fmt.Errorf("expected io.Writer, got nil")
 		}
 
-		c.writer = w
+		c.setWriter(w, nil)
+		c.logStream = ""
 		c.handler = buildHandler(c)
 
 		return nil
@@ -159,7 +267,7 @@ func WithExtraContextFields(fields ...any) Option {
 // NewLogger returns a new Logger with the given log type and level.
 // If no opts are provided, it returns a default logger.
 //
-// The log type can be "text" or "json". The log level can be "debug", "info", "warn" or "error".
+// The log type can be "text", "json", "logfmt" or "console". The log level can be "debug", "info", "warn" or "error".
 //
 // timeTemplate is a time format string. Any format which is valid for time.Time format is acceptable.
 //
@@ -169,7 +277,7 @@ func WithExtraContextFields(fields ...any) Option {
 //
 // If the log type or level is unknown, it returns an error.
 func NewLogger(opts ...Option) (*Logger, error) {
-	cfg := &Config{}
+	cfg := &Config{fatal: fatalConfig{timeout: DefaultFatalTimeout}}
 
 	if err := WithDefaults()(cfg); err != nil {
 		return nil, errors.New("default logger initialization failed")
@@ -177,9 +285,72 @@ func NewLogger(opts ...Option) (*Logger, error) {
 
 	for _, opt := range opts {
 		if err := opt(cfg); err != nil {
+			if cfg.ownedWriter != nil {
+				_ = cfg.ownedWriter.Close()
+			}
 			return nil, fmt.Errorf("logger initialization failed: %w", err)
 		}
 	}
 
-	return &Logger{slog.New(cfg.handler), cfg.extraCtxFields}, nil
+	logg := &Logger{
+		extraCtxFields: cfg.extraCtxFields,
+		levels:         newLevelRegistry(cfg.level, cfg.componentLevels),
+		closer:         cfg.ownedWriter,
+		fatal:          cfg.fatal,
+		addSource:      cfg.addSource,
+		callerSkip:     cfg.callerSkip,
+		trace:          cfg.traceKeys,
+		settings:       &atomic.Pointer[Settings]{},
+	}
+	logg.settings.Store(settingsFromConfig(cfg))
+	if cfg.stacktrace {
+		logg.stacktraceLevel = &cfg.stacktraceLevel
+	}
+	if cfg.spanEvents {
+		logg.spanEventsLevel = &cfg.spanEventsLevel
+	}
+
+	handler := cfg.handler
+	var watcher *configWatcher
+	if cfg.watch != nil {
+		swap := newSwapHandler(handler)
+		watcher = newConfigWatcher(logg, swap.state, cfg)
+		logg.closer = watcher
+		handler = swap
+	}
+	if len(cfg.otlp) > 0 {
+		handlers := []slog.Handler{handler}
+		for _, o := range cfg.otlp {
+			h := &otlpHandler{exp: newOTLPExporter(o)}
+			logg.sinks = append(logg.sinks, h)
+			handlers = append(handlers, h)
+		}
+		handler = multiHandler(handlers)
+	}
+	if cfg.async != nil {
+		logg.async = newAsyncQueue(cfg.async)
+		handler = &asyncHandler{logg.async, handler}
+	}
+	// Sampling, deduplication and rate limiting go first, so suppressed records do not occupy the async queue.
+	// Their reports are written directly, bypassing each other.
+	out := handler
+	if cfg.rateLimits != nil {
+		logg.limiter = newRateLimiter(cfg.rateLimits, out, logg.levels)
+		handler = &filterHandler{filter: logg.limiter, next: handler}
+	}
+	if cfg.dedup.window > 0 {
+		logg.dedup = newDeduplicator(&cfg.dedup, out)
+		handler = &filterHandler{filter: logg.dedup, next: handler}
+	}
+	if cfg.sampling != nil && cfg.sampling.interval > 0 {
+		logg.sampler = newSampler(cfg.sampling, out)
+		handler = &filterHandler{filter: logg.sampler, next: handler}
+	}
+	logg.l = slog.New(handler)
+
+	if watcher != nil {
+		go watcher.run()
+	}
+
+	return logg, nil
 }
@@ -513,3 +513,107 @@ func (s *LoggerTestSuite) TestInvalidConfigTypes() {
 		})
 	}
 }
+
+func (s *LoggerTestSuite) TestSetLevel() {
+	l, err := logger.NewLogger(
+		logger.WithConfig(map[string]any{"level": "info"}),
+		logger.WithWriter(s.writer),
+	)
+	s.Require().NoError(err, "got error, expected nil")
+	derived := l.With("component", "test")
+	s.Require().Equal("INFO", l.Level(), "unexpected initial level")
+
+	s.Run("lower level", func() {
+		s.writer.CleanUp()
+		s.Require().NoError(l.SetLevel("TRACE"), "got error, expected nil")
+		s.Require().Equal("TRACE", derived.Level(), "derived logger level was not changed")
+
+		l.Trace(context.Background(), "trace")
+		derived.Debug(context.Background(), "debug")
+		s.Require().Len(s.writer.arr, 2, "unexpected amount of logs received")
+	})
+
+	s.Run("raise level", func() {
+		s.writer.CleanUp()
+		s.Require().NoError(derived.SetLevel("warn"), "got error, expected nil")
+		s.Require().Equal("WARN", l.Level(), "parent logger level was not changed")
+
+		l.Info(context.Background(), "info")
+		derived.Warn(context.Background(), "warn")
+		s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+	})
+
+	s.Run("unknown level", func() {
+		s.Require().Error(l.SetLevel("unknown"), "got nil, expected error")
+		s.Require().Equal("WARN", l.Level(), "level changed on error")
+	})
+}
+
+func (s *LoggerTestSuite) TestNamed() {
+	l, err := logger.NewLogger(
+		logger.WithConfig(map[string]any{
+			"level":  "info",
+			"levels": map[string]any{"db": "trace", "http.client": "warn"},
+		}),
+		logger.WithWriter(s.writer),
+	)
+	s.Require().NoError(err, "got error, expected nil")
+
+	testCases := []struct {
+		name          string
+		logger        *logger.Logger
+		expectedName  string
+		expectedLevel string
+	}{
+		{"root", l, "", "INFO"},
+		{"configured component", l.Named("db"), "db", "TRACE"},
+		{"inherited level", l.Named("db").Named("pool"), "db.pool", "TRACE"},
+		{"nested configured component", l.Named("http").Named("client"), "http.client", "WARN"},
+		{"parent of configured component", l.Named("http"), "http", "INFO"},
+		{"named after with", l.With("service", "auth").Named("db"), "db", "TRACE"},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			s.writer.CleanUp()
+			s.Require().Equal(tC.expectedLevel, tC.logger.Level(), "unexpected logger level")
+
+			tC.logger.Trace(context.Background(), "trace")
+			tC.logger.Warn(context.Background(), "warn")
+			s.Require().NotEmpty(s.writer.arr, "expected logs to be written")
+			if tC.expectedLevel == "TRACE" {
+				s.Require().Len(s.writer.arr, 2, "unexpected amount of logs received")
+			} else {
+				s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+			}
+
+			var logData map[string]any
+			s.Require().NoError(json.Unmarshal(s.writer.arr[0], &logData), "failed to unmarshal log entry")
+			if tC.expectedName == "" {
+				s.Require().NotContains(logData, "logger", "unexpected logger name")
+			} else {
+				s.Require().Equal(tC.expectedName, logData["logger"], "unexpected logger name")
+			}
+		})
+	}
+
+	s.Run("set component level", func() {
+		s.writer.CleanUp()
+		s.Require().NoError(l.Named("http").SetLevel("debug"), "got error, expected nil")
+		s.Require().Equal("DEBUG", l.Named("http").Named("server").Level(), "level was not inherited")
+		s.Require().Equal("WARN", l.Named("http").Named("client").Level(), "configured level was overwritten")
+		s.Require().Equal("INFO", l.Level(), "root level was changed")
+	})
+
+	s.Run("invalid levels", func() {
+		for _, levels := range []any{
+			map[string]any{"db": "unknown"},
+			map[string]any{"db": 1},
+			map[string]string{"": "info"},
+			"db=trace",
+		} {
+			_, err := logger.NewLogger(logger.WithConfig(map[string]any{"levels": levels}))
+			s.Require().Error(err, "got nil, expected error for %v", levels)
+		}
+	})
+}
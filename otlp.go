@@ -0,0 +1,584 @@
+package logkit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Supported protocols of the OTLP exporter.
+const (
+	// OTLPProtocolProtobuf is OTLP over HTTP with binary protobuf payloads.
+	OTLPProtocolProtobuf = "http/protobuf"
+	// OTLPProtocolJSON is OTLP over HTTP with JSON payloads.
+	OTLPProtocolJSON = "http/json"
+)
+
+// Default settings of the OTLP exporter, applied to zero fields of OTLPOptions.
+const (
+	DefaultOTLPEndpoint      = "http://localhost:4318/v1/logs"
+	DefaultOTLPBatchSize     = 512
+	DefaultOTLPQueueSize     = 2048
+	DefaultOTLPFlushInterval = time.Second
+	DefaultOTLPTimeout       = 10 * time.Second
+	DefaultOTLPMaxRetries    = 5
+	DefaultOTLPRetryBackoff  = 100 * time.Millisecond
+	DefaultOTLPMaxBackoff    = 5 * time.Second
+)
+
+// otlpScopeName is the name of the instrumentation scope of exported records.
+const otlpScopeName = "github.com/Averlex/logkit"
+
+// OTLPOptions configures the OTLP logs exporter, see WithOTLP. Zero fields are replaced with defaults.
+type OTLPOptions struct {
+	Endpoint           string            // URL of OTLP/HTTP logs endpoint, e.g. "https://collector:4318/v1/logs".
+	Protocol           string            // OTLPProtocolProtobuf or OTLPProtocolJSON.
+	Headers            map[string]string // Additional request headers, e.g. authorization ones.
+	ServiceName        string            // "service.name" resource attribute.
+	ServiceVersion     string            // "service.version" resource attribute.
+	ResourceAttributes map[string]string // Additional resource attributes, e.g. "deployment.environment".
+	BatchSize          int               // Maximal number of records in a single request.
+	QueueSize          int               // Maximal number of records awaiting export, new records are dropped above it.
+	FlushInterval      time.Duration     // Interval of exporting incomplete batches.
+	Timeout            time.Duration     // Timeout of a single request.
+	MaxRetries         int               // Maximal number of retries of a failed request, negative value disables retries.
+	RetryBackoff       time.Duration     // Delay before the first retry, doubled for each next one.
+	MaxBackoff         time.Duration     // Maximal delay between retries.
+	Client             *http.Client      // HTTP client, http.DefaultClient if nil.
+	OnError            func(error)       // Called when records are dropped due to export failures or queue overflow.
+}
+
+// WithOTLP adds a sink exporting records using OTLP logs protocol over HTTP. Records are written
+// to the configured output as usual and exported in batches by a background goroutine.
+//
+// Each record is exported with:
+//   - severity number and text, custom levels are mapped to the closest OpenTelemetry severities:
+//     TRACE → TRACE (1), VERBOSE → DEBUG3 (7), FATAL → FATAL (21).
+//   - message as the body and attributes of the record and logger, groups are flattened with dots.
+//   - trace and span IDs of the span in the context passed to the logging method, if any.
+//
+// Failed requests are retried with exponential backoff if the collector is unavailable or throttles requests
+// (429, 502, 503, 504 statuses and network errors), Retry-After header is respected.
+// Logger.Flush exports the buffered records, Logger.Close exports them and stops the exporter.
+func WithOTLP(opts OTLPOptions) Option {
+	return func(c *Config) error {
+		opts, err := opts.withDefaults()
+		if err != nil {
+			return fmt.Errorf("otlp options are invalid: %w", err)
+		}
+		c.otlp = append(c.otlp, opts)
+		return nil
+	}
+}
+
+// withDefaults validates the options and returns them with defaults applied.
+func (o OTLPOptions) withDefaults() (OTLPOptions, error) {
+	if o.Endpoint == "" {
+		o.Endpoint = DefaultOTLPEndpoint
+	}
+	if u, err := url.Parse(o.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return o, fmt.Errorf("invalid endpoint %q", o.Endpoint)
+	}
+
+	switch o.Protocol {
+	case "":
+		o.Protocol = OTLPProtocolProtobuf
+	case OTLPProtocolProtobuf, OTLPProtocolJSON:
+	default:
+		return o, fmt.Errorf("unsupported protocol %q", o.Protocol)
+	}
+
+	if o.BatchSize < 0 || o.QueueSize < 0 || o.FlushInterval < 0 || o.Timeout < 0 ||
+		o.RetryBackoff < 0 || o.MaxBackoff < 0 {
+		return o, errors.New("sizes and durations must not be negative")
+	}
+	setDefault(&o.BatchSize, DefaultOTLPBatchSize)
+	setDefault(&o.QueueSize, max(DefaultOTLPQueueSize, o.BatchSize))
+	setDefault(&o.FlushInterval, DefaultOTLPFlushInterval)
+	setDefault(&o.Timeout, DefaultOTLPTimeout)
+	setDefault(&o.MaxRetries, DefaultOTLPMaxRetries)
+	setDefault(&o.RetryBackoff, DefaultOTLPRetryBackoff)
+	setDefault(&o.MaxBackoff, DefaultOTLPMaxBackoff)
+	if o.Client == nil {
+		o.Client = http.DefaultClient
+	}
+	return o, nil
+}
+
+// setDefault sets zero v to the default value.
+func setDefault[T comparable](v *T, def T) {
+	var zero T
+	if *v == zero {
+		*v = def
+	}
+}
+
+// sink is an additional destination of records, along with the configured output.
+type sink interface {
+	slog.Handler
+	// flush exports the buffered records.
+	flush(ctx context.Context) error
+	// close exports the buffered records and releases the resources.
+	close(ctx context.Context) error
+}
+
+// multiHandler is a slog.Handler passing records to all of its handlers.
+type multiHandler []slog.Handler
+
+// Enabled implements slog.Handler.
+func (h multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, hh := range h {
+		if hh.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle implements slog.Handler.
+func (h multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, hh := range h {
+		if hh.Enabled(ctx, r.Level) {
+			errs = append(errs, hh.Handle(ctx, r))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs implements slog.Handler.
+func (h multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	res := make(multiHandler, len(h))
+	for i, hh := range h {
+		res[i] = hh.WithAttrs(attrs)
+	}
+	return res
+}
+
+// WithGroup implements slog.Handler.
+func (h multiHandler) WithGroup(name string) slog.Handler {
+	res := make(multiHandler, len(h))
+	for i, hh := range h {
+		res[i] = hh.WithGroup(name)
+	}
+	return res
+}
+
+// otlpKeyValue is an attribute of an exported record or resource.
+// The value is one of: string, bool, int64, float64, []byte or []any of them.
+type otlpKeyValue struct {
+	key   string
+	value any
+}
+
+// otlpRecord is a record prepared for the export.
+type otlpRecord struct {
+	time         time.Time
+	observedTime time.Time
+	severity     int
+	severityText string
+	body         string
+	attrs        []otlpKeyValue
+	traceID      trace.TraceID
+	spanID       trace.SpanID
+	traceFlags   trace.TraceFlags
+}
+
+// otlpExporter buffers records and exports them in batches.
+type otlpExporter struct {
+	opts     OTLPOptions
+	resource []otlpKeyValue
+
+	mu     sync.Mutex
+	queue  []otlpRecord
+	closed bool
+
+	wake    chan struct{}      // Signals that a full batch is buffered.
+	flushes chan chan struct{} // Requests to export all buffered records.
+	stop    chan struct{}
+	done    chan struct{}
+	cancel  context.CancelFunc // Cancels in-flight requests.
+	ctx     context.Context
+}
+
+// newOTLPExporter returns a new exporter and starts its background goroutine.
+func newOTLPExporter(opts OTLPOptions) *otlpExporter {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &otlpExporter{
+		opts:     opts,
+		resource: otlpResource(opts),
+		wake:     make(chan struct{}, 1),
+		flushes:  make(chan chan struct{}),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	go e.run()
+	return e
+}
+
+// otlpResource returns sorted resource attributes.
+func otlpResource(opts OTLPOptions) []otlpKeyValue {
+	attrs := make(map[string]string, len(opts.ResourceAttributes)+2)
+	for k, v := range opts.ResourceAttributes {
+		attrs[k] = v
+	}
+	if opts.ServiceName != "" {
+		attrs["service.name"] = opts.ServiceName
+	}
+	if opts.ServiceVersion != "" {
+		attrs["service.version"] = opts.ServiceVersion
+	}
+
+	res := make([]otlpKeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		res = append(res, otlpKeyValue{k, v})
+	}
+	slices.SortFunc(res, func(a, b otlpKeyValue) int { return strings.Compare(a.key, b.key) })
+	return res
+}
+
+// enqueue adds the record to the queue. The record is dropped if the queue is full.
+func (e *otlpExporter) enqueue(rec otlpRecord) {
+	e.mu.Lock()
+	var err error
+	switch {
+	case e.closed:
+		err = errors.New("otlp exporter is closed")
+	case len(e.queue) >= e.opts.QueueSize:
+		err = errors.New("otlp export queue is full")
+	}
+	if err != nil {
+		e.mu.Unlock()
+		e.drop(1, err)
+		return
+	}
+	e.queue = append(e.queue, rec)
+	full := len(e.queue) >= e.opts.BatchSize
+	e.mu.Unlock()
+
+	if full {
+		select {
+		case e.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// drop reports the dropped records.
+func (e *otlpExporter) drop(n int, err error) {
+	if e.opts.OnError != nil {
+		e.opts.OnError(fmt.Errorf("%d records dropped: %w", n, err))
+	}
+}
+
+// run exports the records until the exporter is closed.
+func (e *otlpExporter) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.stop:
+			e.export(true)
+			return
+		case <-ticker.C:
+			e.export(true)
+		case <-e.wake:
+			e.export(false)
+		case done := <-e.flushes:
+			e.export(true)
+			close(done)
+		}
+	}
+}
+
+// export sends the buffered records in batches. If all is false, incomplete batches are kept in the queue.
+func (e *otlpExporter) export(all bool) {
+	for {
+		e.mu.Lock()
+		if len(e.queue) == 0 || (!all && len(e.queue) < e.opts.BatchSize) {
+			e.mu.Unlock()
+			return
+		}
+		n := min(len(e.queue), e.opts.BatchSize)
+		batch := slices.Clone(e.queue[:n])
+		e.queue = slices.Delete(e.queue, 0, n)
+		e.mu.Unlock()
+
+		if err := e.send(batch); err != nil {
+			e.drop(len(batch), err)
+		}
+	}
+}
+
+// send encodes the batch and sends it, retrying on transient failures.
+func (e *otlpExporter) send(batch []otlpRecord) error {
+	var (
+		body        []byte
+		contentType string
+	)
+	if e.opts.Protocol == OTLPProtocolJSON {
+		body, contentType = encodeOTLPJSON(e.resource, batch), "application/json"
+	} else {
+		body, contentType = encodeOTLPProtobuf(e.resource, batch), "application/x-protobuf"
+	}
+
+	backoff := e.opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := e.post(body, contentType)
+		var permanent *otlpPermanentError
+		if err == nil || errors.As(err, &permanent) || attempt >= e.opts.MaxRetries {
+			return err
+		}
+
+		delay := backoff
+		if retryAfter > 0 {
+			delay = retryAfter
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-e.ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("retry aborted: %w", err)
+		}
+		backoff = min(backoff*2, e.opts.MaxBackoff)
+	}
+}
+
+// otlpPermanentError is an export error which must not be retried.
+type otlpPermanentError struct {
+	status int
+	body   string
+}
+
+// Error implements error.
+func (e *otlpPermanentError) Error() string {
+	return fmt.Sprintf("otlp export rejected with status %d: %s", e.status, e.body)
+}
+
+// post sends a single request. For retryable failures the delay requested by the collector is returned, if any.
+func (e *otlpExporter) post(body []byte, contentType string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(e.ctx, e.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.opts.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, &otlpPermanentError{body: err.Error()}
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range e.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.opts.Client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("otlp export failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return 0, nil
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusBadGateway,
+		resp.StatusCode == http.StatusServiceUnavailable, resp.StatusCode == http.StatusGatewayTimeout:
+		var retryAfter time.Duration
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		return retryAfter, fmt.Errorf("otlp export failed with status %d", resp.StatusCode)
+	default:
+		return 0, &otlpPermanentError{status: resp.StatusCode, body: string(respBody)}
+	}
+}
+
+// flush exports all buffered records.
+func (e *otlpExporter) flush(ctx context.Context) error {
+	done := make(chan struct{})
+	select {
+	case e.flushes <- done:
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close exports all buffered records and stops the exporter. If ctx is done earlier,
+// in-flight requests are cancelled and the remaining records are dropped.
+func (e *otlpExporter) close(ctx context.Context) error {
+	e.mu.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.stop)
+	}
+	e.mu.Unlock()
+
+	select {
+	case <-e.done:
+		e.cancel()
+		return nil
+	case <-ctx.Done():
+		e.cancel()
+		return ctx.Err()
+	}
+}
+
+// otlpHandler is a slog.Handler converting records for the OTLP exporter.
+type otlpHandler struct {
+	exp    *otlpExporter
+	attrs  []otlpKeyValue // Attributes added via WithAttrs.
+	prefix string         // Dotted prefix of the open groups.
+}
+
+// Enabled implements slog.Handler. Records are filtered by the logger.
+func (h *otlpHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle implements slog.Handler.
+func (h *otlpHandler) Handle(ctx context.Context, r slog.Record) error {
+	rec := otlpRecord{
+		time:         r.Time,
+		observedTime: time.Now(),
+		severity:     otlpSeverity(r.Level),
+		severityText: levelName(r.Level),
+		body:         r.Message,
+		attrs:        slices.Clip(h.attrs),
+	}
+	if r.PC != 0 {
+		src := recordSource(r)
+		rec.attrs = append(rec.attrs,
+			otlpKeyValue{"code.function.name", src.Function},
+			otlpKeyValue{"code.file.path", src.File},
+			otlpKeyValue{"code.line.number", int64(src.Line)},
+		)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		rec.attrs = appendOTLPAttrs(rec.attrs, h.prefix, a)
+		return true
+	})
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		rec.traceID, rec.spanID, rec.traceFlags = sc.TraceID(), sc.SpanID(), sc.TraceFlags()
+	}
+
+	h.exp.enqueue(rec)
+	return nil
+}
+
+// WithAttrs implements slog.Handler.
+func (h *otlpHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.attrs = slices.Clip(h.attrs)
+	for _, a := range attrs {
+		h2.attrs = appendOTLPAttrs(h2.attrs, h.prefix, a)
+	}
+	return &h2
+}
+
+// WithGroup implements slog.Handler.
+func (h *otlpHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+// flush implements sink.
+func (h *otlpHandler) flush(ctx context.Context) error {
+	return h.exp.flush(ctx)
+}
+
+// close implements sink.
+func (h *otlpHandler) close(ctx context.Context) error {
+	return h.exp.close(ctx)
+}
+
+// appendOTLPAttrs converts the slog attribute to OTLP ones. Groups are flattened with dots.
+func appendOTLPAttrs(attrs []otlpKeyValue, prefix string, a slog.Attr) []otlpKeyValue {
+	flattenAttr(nil, prefix, nil, a, func(key string, v slog.Value) {
+		attrs = append(attrs, otlpKeyValue{key, otlpValue(v)})
+	})
+	return attrs
+}
+
+// otlpValue converts the resolved slog value to OTLP one.
+func otlpValue(v slog.Value) any {
+	switch v.Kind() {
+	case slog.KindString:
+		return v.String()
+	case slog.KindInt64:
+		return v.Int64()
+	case slog.KindUint64:
+		if u := v.Uint64(); u <= 1<<63-1 {
+			return int64(u)
+		}
+		return v.String()
+	case slog.KindFloat64:
+		return v.Float64()
+	case slog.KindBool:
+		return v.Bool()
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	}
+
+	switch val := v.Any().(type) {
+	case []byte:
+		return val
+	case []string:
+		res := make([]any, len(val))
+		for i, s := range val {
+			res[i] = s
+		}
+		return res
+	default:
+		return anyToString(val)
+	}
+}
+
+// otlpSeverity returns OpenTelemetry severity number of the level.
+// Standard slog levels are mapped as the OpenTelemetry slog bridge does, custom ones - to the closest severities.
+func otlpSeverity(level slog.Level) int {
+	switch level {
+	case LevelTrace:
+		return 1 // TRACE
+	case LevelVerbose:
+		return 7 // DEBUG3
+	case LevelFatal:
+		return 21 // FATAL
+	}
+	return min(max(int(level)+9, 1), 24)
+}
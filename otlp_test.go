@@ -0,0 +1,269 @@
+package logkit_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	logger "github.com/Averlex/logkit"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// otlpCollector is a test OTLP/HTTP endpoint recording the received requests.
+type otlpCollector struct {
+	mu       sync.Mutex
+	bodies   [][]byte
+	types    []string
+	statuses []int // Statuses of the subsequent responses, 200 when exhausted.
+}
+
+func (c *otlpCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bodies = append(c.bodies, body)
+	c.types = append(c.types, r.Header.Get("Content-Type"))
+	if len(c.statuses) > 0 {
+		w.WriteHeader(c.statuses[0])
+		c.statuses = c.statuses[1:]
+	}
+}
+
+func (c *otlpCollector) requests() ([][]byte, []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.bodies, c.types
+}
+
+// protoFields decodes the protobuf message into field values: []byte for length-delimited fields, uint64 for others.
+func protoFields(b []byte) map[protowire.Number][]any {
+	res := make(map[protowire.Number][]any)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		b = b[n:]
+		var v any
+		switch typ {
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			v, n = protowire.ConsumeFixed64(b)
+		case protowire.Fixed32Type:
+			var u uint32
+			u, n = protowire.ConsumeFixed32(b)
+			v = uint64(u)
+		default:
+			n = -1
+		}
+		if n < 0 {
+			panic("malformed protobuf message")
+		}
+		res[num] = append(res[num], v)
+		b = b[n:]
+	}
+	return res
+}
+
+func (s *LoggerTestSuite) TestOTLP() {
+	newLogger := func(opts logger.OTLPOptions, statuses ...int) (*logger.Logger, *otlpCollector) {
+		collector := &otlpCollector{statuses: statuses}
+		srv := httptest.NewServer(collector)
+		s.T().Cleanup(srv.Close)
+
+		opts.Endpoint = srv.URL
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "trace"}),
+			logger.WithWriter(s.writer),
+			logger.WithOTLP(opts),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+		return l, collector
+	}
+
+	s.Run("json", func() {
+		s.writer.CleanUp()
+		l, collector := newLogger(logger.OTLPOptions{
+			Protocol:           logger.OTLPProtocolJSON,
+			ServiceName:        "billing",
+			ServiceVersion:     "1.2.0",
+			ResourceAttributes: map[string]string{"deployment.environment": "test"},
+		})
+
+		l.With("component", "db").Info(spanContext(), "query executed", slog.Group("req", "rows", 3, "ok", true))
+		l.Trace(context.Background(), "fine-grained")
+		s.Require().NoError(l.Close(context.Background()), "got error, expected nil")
+		s.Require().Len(s.writer.arr, 2, "records must be written to the output as well")
+
+		bodies, types := collector.requests()
+		s.Require().Len(bodies, 1, "unexpected amount of requests")
+		s.Require().Equal("application/json", types[0], "unexpected content type")
+
+		var req struct {
+			ResourceLogs []struct {
+				Resource struct {
+					Attributes []map[string]any `json:"attributes"`
+				} `json:"resource"`
+				ScopeLogs []struct {
+					LogRecords []map[string]any `json:"logRecords"`
+				} `json:"scopeLogs"`
+			} `json:"resourceLogs"`
+		}
+		s.Require().NoError(json.Unmarshal(bodies[0], &req), "failed to unmarshal request")
+		s.Require().Equal([]map[string]any{
+			{"key": "deployment.environment", "value": map[string]any{"stringValue": "test"}},
+			{"key": "service.name", "value": map[string]any{"stringValue": "billing"}},
+			{"key": "service.version", "value": map[string]any{"stringValue": "1.2.0"}},
+		}, req.ResourceLogs[0].Resource.Attributes, "unexpected resource attributes")
+
+		records := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+		s.Require().Len(records, 2, "unexpected amount of records")
+
+		rec := records[0]
+		s.Require().Equal(float64(9), rec["severityNumber"], "unexpected severity number")
+		s.Require().Equal("INFO", rec["severityText"], "unexpected severity text")
+		s.Require().Equal(map[string]any{"stringValue": "query executed"}, rec["body"], "unexpected body")
+		s.Require().Equal("4bf92f3577b34da6a3ce929d0e0e4736", rec["traceId"], "unexpected trace ID")
+		s.Require().Equal("00f067aa0ba902b7", rec["spanId"], "unexpected span ID")
+		s.Require().Equal(float64(1), rec["flags"], "unexpected trace flags")
+		s.Require().Equal([]any{
+			map[string]any{"key": "component", "value": map[string]any{"stringValue": "db"}},
+			map[string]any{"key": "req.rows", "value": map[string]any{"intValue": "3"}},
+			map[string]any{"key": "req.ok", "value": map[string]any{"boolValue": true}},
+		}, rec["attributes"], "unexpected attributes")
+
+		rec = records[1]
+		s.Require().Equal(float64(1), rec["severityNumber"], "unexpected severity number")
+		s.Require().Equal("TRACE", rec["severityText"], "unexpected severity text")
+		s.Require().NotContains(rec, "traceId", "unexpected trace ID")
+	})
+
+	s.Run("json special floats", func() {
+		l, collector := newLogger(logger.OTLPOptions{Protocol: logger.OTLPProtocolJSON})
+
+		l.Info(context.Background(), "floats", "inf", math.Inf(1), "ninf", math.Inf(-1), "nan", math.NaN(), "num", 1.5)
+		s.Require().NoError(l.Close(context.Background()), "got error, expected nil")
+
+		bodies, _ := collector.requests()
+		s.Require().Len(bodies, 1, "unexpected amount of requests")
+		var req struct {
+			ResourceLogs []struct {
+				ScopeLogs []struct {
+					LogRecords []map[string]any `json:"logRecords"`
+				} `json:"scopeLogs"`
+			} `json:"resourceLogs"`
+		}
+		s.Require().NoError(json.Unmarshal(bodies[0], &req), "failed to unmarshal request")
+		s.Require().Equal([]any{
+			map[string]any{"key": "inf", "value": map[string]any{"doubleValue": "Infinity"}},
+			map[string]any{"key": "ninf", "value": map[string]any{"doubleValue": "-Infinity"}},
+			map[string]any{"key": "nan", "value": map[string]any{"doubleValue": "NaN"}},
+			map[string]any{"key": "num", "value": map[string]any{"doubleValue": 1.5}},
+		}, req.ResourceLogs[0].ScopeLogs[0].LogRecords[0]["attributes"], "unexpected attributes")
+	})
+
+	s.Run("protobuf", func() {
+		l, collector := newLogger(logger.OTLPOptions{ServiceName: "billing"})
+
+		l.Verbose(spanContext(), "verbose")
+		l.Error(context.Background(), "failed")
+		s.Require().NoError(l.Close(context.Background()), "got error, expected nil")
+
+		bodies, types := collector.requests()
+		s.Require().Len(bodies, 1, "unexpected amount of requests")
+		s.Require().Equal("application/x-protobuf", types[0], "unexpected content type")
+
+		resourceLogs := protoFields(protoFields(bodies[0])[1][0].([]byte))
+		resource := protoFields(resourceLogs[1][0].([]byte))
+		attr := protoFields(resource[1][0].([]byte))
+		s.Require().Equal("service.name", string(attr[1][0].([]byte)), "unexpected resource attribute key")
+		s.Require().Equal("billing", string(protoFields(attr[2][0].([]byte))[1][0].([]byte)),
+			"unexpected resource attribute value")
+
+		scopeLogs := protoFields(resourceLogs[2][0].([]byte))
+		s.Require().Len(scopeLogs[2], 2, "unexpected amount of records")
+
+		rec := protoFields(scopeLogs[2][0].([]byte))
+		s.Require().Equal(uint64(7), rec[2][0], "unexpected severity number")
+		s.Require().Equal("VERBOSE", string(rec[3][0].([]byte)), "unexpected severity text")
+		s.Require().Equal("verbose", string(protoFields(rec[5][0].([]byte))[1][0].([]byte)), "unexpected body")
+		s.Require().Equal(
+			[]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+			rec[9][0], "unexpected trace ID")
+
+		rec = protoFields(scopeLogs[2][1].([]byte))
+		s.Require().Equal(uint64(17), rec[2][0], "unexpected severity number")
+		s.Require().NotContains(rec, protowire.Number(9), "unexpected trace ID")
+	})
+
+	s.Run("batching", func() {
+		l, collector := newLogger(logger.OTLPOptions{BatchSize: 2, FlushInterval: time.Hour})
+
+		for range 5 {
+			l.Info(context.Background(), "batched")
+		}
+		s.Require().Eventually(func() bool {
+			bodies, _ := collector.requests()
+			return len(bodies) == 2
+		}, time.Second, 10*time.Millisecond, "full batches must be exported without waiting")
+
+		s.Require().NoError(l.Flush(context.Background()), "got error, expected nil")
+		bodies, _ := collector.requests()
+		s.Require().Len(bodies, 3, "incomplete batch must be exported on flush")
+		for i, expected := range []int{2, 2, 1} {
+			scopeLogs := protoFields(protoFields(protoFields(bodies[i])[1][0].([]byte))[2][0].([]byte))
+			s.Require().Len(scopeLogs[2], expected, "unexpected batch size")
+		}
+		s.Require().NoError(l.Close(context.Background()), "got error, expected nil")
+	})
+
+	s.Run("retry", func() {
+		var errs []error
+		l, collector := newLogger(logger.OTLPOptions{
+			RetryBackoff: time.Millisecond,
+			OnError:      func(err error) { errs = append(errs, err) },
+		}, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+
+		l.Info(context.Background(), "retried")
+		s.Require().NoError(l.Close(context.Background()), "got error, expected nil")
+
+		bodies, _ := collector.requests()
+		s.Require().Len(bodies, 3, "unexpected amount of requests")
+		s.Require().Equal(bodies[0], bodies[2], "retried request must be the same")
+		s.Require().Empty(errs, "unexpected export errors")
+	})
+
+	s.Run("permanent error", func() {
+		var errs []error
+		l, collector := newLogger(logger.OTLPOptions{
+			RetryBackoff: time.Millisecond,
+			OnError:      func(err error) { errs = append(errs, err) },
+		}, http.StatusBadRequest)
+
+		l.Info(context.Background(), "rejected")
+		s.Require().NoError(l.Close(context.Background()), "got error, expected nil")
+
+		bodies, _ := collector.requests()
+		s.Require().Len(bodies, 1, "rejected request must not be retried")
+		s.Require().Len(errs, 1, "unexpected amount of export errors")
+		s.Require().ErrorContains(errs[0], "status 400", "unexpected export error")
+	})
+
+	s.Run("invalid options", func() {
+		for _, opts := range []logger.OTLPOptions{
+			{Protocol: "grpc"},
+			{Endpoint: "localhost:4318"},
+			{BatchSize: -1},
+		} {
+			_, err := logger.NewLogger(logger.WithOTLP(opts))
+			s.Require().Error(err, "got nil, expected error")
+		}
+	})
+}
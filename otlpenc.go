@@ -0,0 +1,250 @@
+package logkit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of OTLP logs protobuf messages, see opentelemetry/proto/logs/v1/logs.proto
+// and opentelemetry/proto/common/v1/common.proto.
+const (
+	protoRequestResourceLogs = 1 // ExportLogsServiceRequest.resource_logs
+
+	protoResourceLogsResource  = 1 // ResourceLogs.resource
+	protoResourceLogsScopeLogs = 2 // ResourceLogs.scope_logs
+	protoResourceAttributes    = 1 // Resource.attributes
+
+	protoScopeLogsScope      = 1 // ScopeLogs.scope
+	protoScopeLogsLogRecords = 2 // ScopeLogs.log_records
+	protoScopeName           = 1 // InstrumentationScope.name
+
+	protoRecordTime           = 1  // LogRecord.time_unix_nano
+	protoRecordSeverityNumber = 2  // LogRecord.severity_number
+	protoRecordSeverityText   = 3  // LogRecord.severity_text
+	protoRecordBody           = 5  // LogRecord.body
+	protoRecordAttributes     = 6  // LogRecord.attributes
+	protoRecordFlags          = 8  // LogRecord.flags
+	protoRecordTraceID        = 9  // LogRecord.trace_id
+	protoRecordSpanID         = 10 // LogRecord.span_id
+	protoRecordObservedTime   = 11 // LogRecord.observed_time_unix_nano
+
+	protoKeyValueKey   = 1 // KeyValue.key
+	protoKeyValueValue = 2 // KeyValue.value
+
+	protoValueString = 1 // AnyValue.string_value
+	protoValueBool   = 2 // AnyValue.bool_value
+	protoValueInt    = 3 // AnyValue.int_value
+	protoValueDouble = 4 // AnyValue.double_value
+	protoValueArray  = 5 // AnyValue.array_value
+	protoValueBytes  = 7 // AnyValue.bytes_value
+	protoArrayValues = 1 // ArrayValue.values
+)
+
+// encodeOTLPProtobuf encodes the records as ExportLogsServiceRequest protobuf message.
+func encodeOTLPProtobuf(resource []otlpKeyValue, records []otlpRecord) []byte {
+	var res []byte
+	for _, kv := range resource {
+		res = appendProtoMessage(res, protoResourceAttributes, appendProtoKeyValue(nil, kv))
+	}
+
+	var scope []byte
+	scope = appendProtoMessage(scope, protoScopeLogsScope, appendProtoString(nil, protoScopeName, otlpScopeName))
+	for _, rec := range records {
+		scope = appendProtoMessage(scope, protoScopeLogsLogRecords, appendProtoRecord(nil, rec))
+	}
+
+	var rl []byte
+	rl = appendProtoMessage(rl, protoResourceLogsResource, res)
+	rl = appendProtoMessage(rl, protoResourceLogsScopeLogs, scope)
+
+	return appendProtoMessage(nil, protoRequestResourceLogs, rl)
+}
+
+// appendProtoRecord appends LogRecord message fields.
+func appendProtoRecord(b []byte, rec otlpRecord) []byte {
+	if !rec.time.IsZero() {
+		b = protowire.AppendTag(b, protoRecordTime, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, uint64(rec.time.UnixNano()))
+	}
+	b = protowire.AppendTag(b, protoRecordSeverityNumber, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(rec.severity))
+	b = appendProtoString(b, protoRecordSeverityText, rec.severityText)
+	b = appendProtoMessage(b, protoRecordBody, appendProtoValue(nil, rec.body))
+	for _, kv := range rec.attrs {
+		b = appendProtoMessage(b, protoRecordAttributes, appendProtoKeyValue(nil, kv))
+	}
+	if rec.traceID.IsValid() {
+		b = protowire.AppendTag(b, protoRecordFlags, protowire.Fixed32Type)
+		b = protowire.AppendFixed32(b, uint32(rec.traceFlags))
+		b = protowire.AppendTag(b, protoRecordTraceID, protowire.BytesType)
+		b = protowire.AppendBytes(b, rec.traceID[:])
+		b = protowire.AppendTag(b, protoRecordSpanID, protowire.BytesType)
+		b = protowire.AppendBytes(b, rec.spanID[:])
+	}
+	b = protowire.AppendTag(b, protoRecordObservedTime, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, uint64(rec.observedTime.UnixNano()))
+}
+
+// appendProtoKeyValue appends KeyValue message fields.
+func appendProtoKeyValue(b []byte, kv otlpKeyValue) []byte {
+	b = appendProtoString(b, protoKeyValueKey, kv.key)
+	return appendProtoMessage(b, protoKeyValueValue, appendProtoValue(nil, kv.value))
+}
+
+// appendProtoValue appends AnyValue message fields.
+func appendProtoValue(b []byte, v any) []byte {
+	switch v := v.(type) {
+	case string:
+		return appendProtoString(b, protoValueString, v)
+	case bool:
+		b = protowire.AppendTag(b, protoValueBool, protowire.VarintType)
+		return protowire.AppendVarint(b, protowire.EncodeBool(v))
+	case int64:
+		b = protowire.AppendTag(b, protoValueInt, protowire.VarintType)
+		return protowire.AppendVarint(b, uint64(v))
+	case float64:
+		b = protowire.AppendTag(b, protoValueDouble, protowire.Fixed64Type)
+		return protowire.AppendFixed64(b, math.Float64bits(v))
+	case []byte:
+		b = protowire.AppendTag(b, protoValueBytes, protowire.BytesType)
+		return protowire.AppendBytes(b, v)
+	case []any:
+		var arr []byte
+		for _, item := range v {
+			arr = appendProtoMessage(arr, protoArrayValues, appendProtoValue(nil, item))
+		}
+		return appendProtoMessage(b, protoValueArray, arr)
+	default:
+		return b
+	}
+}
+
+// appendProtoString appends the string field.
+func appendProtoString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+// appendProtoMessage appends the encoded message as the field.
+func appendProtoMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+// OTLP/JSON representation of the messages. Field names are lowerCamelCase, 64-bit integers are strings,
+// trace and span IDs are hex strings, as required by OTLP specification.
+type (
+	otlpJSONRequest struct {
+		ResourceLogs []otlpJSONResourceLogs `json:"resourceLogs"`
+	}
+	otlpJSONResourceLogs struct {
+		Resource  otlpJSONResource    `json:"resource"`
+		ScopeLogs []otlpJSONScopeLogs `json:"scopeLogs"`
+	}
+	otlpJSONResource struct {
+		Attributes []otlpJSONKeyValue `json:"attributes"`
+	}
+	otlpJSONScopeLogs struct {
+		Scope      otlpJSONScope       `json:"scope"`
+		LogRecords []otlpJSONLogRecord `json:"logRecords"`
+	}
+	otlpJSONScope struct {
+		Name string `json:"name"`
+	}
+	otlpJSONLogRecord struct {
+		TimeUnixNano         string             `json:"timeUnixNano,omitempty"`
+		ObservedTimeUnixNano string             `json:"observedTimeUnixNano"`
+		SeverityNumber       int                `json:"severityNumber"`
+		SeverityText         string             `json:"severityText"`
+		Body                 map[string]any     `json:"body"`
+		Attributes           []otlpJSONKeyValue `json:"attributes,omitempty"`
+		Flags                uint32             `json:"flags,omitempty"`
+		TraceID              string             `json:"traceId,omitempty"`
+		SpanID               string             `json:"spanId,omitempty"`
+	}
+	otlpJSONKeyValue struct {
+		Key   string         `json:"key"`
+		Value map[string]any `json:"value"`
+	}
+)
+
+// encodeOTLPJSON encodes the records as ExportLogsServiceRequest in OTLP/JSON format.
+func encodeOTLPJSON(resource []otlpKeyValue, records []otlpRecord) []byte {
+	req := otlpJSONRequest{ResourceLogs: []otlpJSONResourceLogs{{
+		Resource: otlpJSONResource{Attributes: otlpJSONKeyValues(resource)},
+		ScopeLogs: []otlpJSONScopeLogs{{
+			Scope:      otlpJSONScope{Name: otlpScopeName},
+			LogRecords: make([]otlpJSONLogRecord, 0, len(records)),
+		}},
+	}}}
+
+	scope := &req.ResourceLogs[0].ScopeLogs[0]
+	for _, rec := range records {
+		r := otlpJSONLogRecord{
+			ObservedTimeUnixNano: strconv.FormatInt(rec.observedTime.UnixNano(), 10),
+			SeverityNumber:       rec.severity,
+			SeverityText:         rec.severityText,
+			Body:                 otlpJSONValue(rec.body),
+			Attributes:           otlpJSONKeyValues(rec.attrs),
+		}
+		if !rec.time.IsZero() {
+			r.TimeUnixNano = strconv.FormatInt(rec.time.UnixNano(), 10)
+		}
+		if rec.traceID.IsValid() {
+			r.Flags = uint32(rec.traceFlags)
+			r.TraceID = rec.traceID.String()
+			r.SpanID = rec.spanID.String()
+		}
+		scope.LogRecords = append(scope.LogRecords, r)
+	}
+
+	// Marshaling can not fail, as all values are strings, numbers, booleans, slices and maps of them.
+	data, _ := json.Marshal(req)
+	return data
+}
+
+// otlpJSONKeyValues converts the attributes to OTLP/JSON form.
+func otlpJSONKeyValues(attrs []otlpKeyValue) []otlpJSONKeyValue {
+	res := make([]otlpJSONKeyValue, len(attrs))
+	for i, kv := range attrs {
+		res[i] = otlpJSONKeyValue{kv.key, otlpJSONValue(kv.value)}
+	}
+	return res
+}
+
+// otlpJSONValue converts the value to OTLP/JSON AnyValue form.
+func otlpJSONValue(v any) map[string]any {
+	switch v := v.(type) {
+	case string:
+		return map[string]any{"stringValue": v}
+	case bool:
+		return map[string]any{"boolValue": v}
+	case int64:
+		return map[string]any{"intValue": strconv.FormatInt(v, 10)}
+	case float64:
+		// JSON has no representation of these values, protobuf JSON mapping uses special strings.
+		switch {
+		case math.IsNaN(v):
+			return map[string]any{"doubleValue": "NaN"}
+		case math.IsInf(v, 1):
+			return map[string]any{"doubleValue": "Infinity"}
+		case math.IsInf(v, -1):
+			return map[string]any{"doubleValue": "-Infinity"}
+		}
+		return map[string]any{"doubleValue": v}
+	case []byte:
+		return map[string]any{"bytesValue": base64.StdEncoding.EncodeToString(v)}
+	case []any:
+		values := make([]map[string]any, len(v))
+		for i, item := range v {
+			values[i] = otlpJSONValue(item)
+		}
+		return map[string]any{"arrayValue": map[string]any{"values": values}}
+	default:
+		return map[string]any{}
+	}
+}
@@ -0,0 +1,301 @@
+package logkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimitReportInterval is a default interval of reporting records dropped by the rate limiting.
+const DefaultRateLimitReportInterval = 10 * time.Second
+
+// Rate is a token bucket limit: records are allowed at PerSecond rate on average, with bursts of up to Burst records.
+// Zero Burst is treated as 1.
+type Rate struct {
+	PerSecond float64
+	Burst     int
+}
+
+// validate checks the rate and returns it with the default burst applied.
+func (r Rate) validate() (Rate, error) {
+	if r.PerSecond < 0 || r.Burst < 0 {
+		return r, fmt.Errorf("rate %v/s with burst %d must not be negative", r.PerSecond, r.Burst)
+	}
+	setDefault(&r.Burst, 1)
+	return r, nil
+}
+
+// rateLimitConfig defines a configuration of the rate limiting.
+type rateLimitConfig struct {
+	levels   map[slog.Level]Rate
+	attrKey  string
+	attrRate Rate
+	interval time.Duration
+}
+
+// rateLimit returns the rate limiting configuration, creating it if needed.
+func (c *Config) rateLimit() *rateLimitConfig {
+	if c.rateLimits == nil {
+		c.rateLimits = &rateLimitConfig{interval: DefaultRateLimitReportInterval}
+	}
+	return c.rateLimits
+}
+
+// WithRateLimit caps the throughput of records per level. Records of levels without limits are not limited;
+// limits are applied to exact levels, e.g. a limit of LevelInfo does not affect LevelWarn records.
+// Records exceeding the limits are dropped.
+//
+// Amounts of dropped records are available via Logger.RateLimitStats and reported periodically,
+// see WithRateLimitReportInterval.
+//
+// Example:
+//
+//	logger, _ := NewLogger(WithRateLimit(map[slog.Level]Rate{
+//		LevelDebug: {PerSecond: 100, Burst: 500},
+//		LevelInfo:  {PerSecond: 1000, Burst: 2000},
+//	}))
+func WithRateLimit(limits map[slog.Level]Rate) Option {
+	return func(c *Config) error {
+		validated := make(map[slog.Level]Rate, len(limits))
+		for level, rate := range limits {
+			rate, err := rate.validate()
+			if err != nil {
+				return fmt.Errorf("invalid rate limit of %s level: %w", levelName(level), err)
+			}
+			validated[level] = rate
+		}
+		c.rateLimit().levels = validated
+		return nil
+	}
+}
+
+// WithRateLimitByAttr caps the throughput of records per value of the attribute with the given key,
+// e.g. "tenant_id", so a single noisy value can not drown out the rest. Each value has its own limit,
+// records without the attribute are not limited. The attribute is looked up in the record attributes,
+// including the ones added from the context, and in the ones added via Logger.With.
+//
+// The limit is applied along with the level ones, see WithRateLimit: a record is written only if
+// none of the limits is exceeded.
+func WithRateLimitByAttr(key string, rate Rate) Option {
+	return func(c *Config) error {
+		if key == "" {
+			return errors.New("rate limit attribute key must not be empty")
+		}
+		rate, err := rate.validate()
+		if err != nil {
+			return fmt.Errorf("invalid rate limit of %q attribute: %w", key, err)
+		}
+		c.rateLimit().attrKey, c.rateLimit().attrRate = key, rate
+		return nil
+	}
+}
+
+// WithRateLimitReportInterval sets the interval of reporting records dropped by the rate limiting,
+// DefaultRateLimitReportInterval by default. For each level and attribute value with dropped records,
+// a record with WARN level and message "records dropped by rate limiting" is written, holding the amount
+// under SuppressedKey and either the level under LimitedLevelKey or the attribute value under its key.
+// Reports are skipped if the logger level is above WARN; the amounts are available via Logger.RateLimitStats anyway.
+// The reporting is stopped on Logger.Close, reporting the records dropped in the last interval;
+// records logged after that are not limited.
+func WithRateLimitReportInterval(interval time.Duration) Option {
+	return func(c *Config) error {
+		if interval <= 0 {
+			return errors.New("rate limit report interval must be positive")
+		}
+		c.rateLimit().interval = interval
+		return nil
+	}
+}
+
+// RateLimitStats holds the amounts of records dropped by the rate limiting since the logger creation.
+type RateLimitStats struct {
+	ByLevel map[slog.Level]uint64 // Records dropped by the level limits.
+	ByAttr  map[string]uint64     // Records dropped by the attribute limit, per attribute value.
+}
+
+// RateLimitStats returns the amounts of records dropped by the rate limiting.
+// Without the rate limiting, empty stats are returned.
+func (logg Logger) RateLimitStats() RateLimitStats {
+	if logg.limiter == nil {
+		return RateLimitStats{}
+	}
+	return logg.limiter.stats()
+}
+
+// tokenBucket is a state of a token bucket limit.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *tokenBucket) refill(rate Rate, now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(float64(rate.Burst), b.tokens+elapsed.Seconds()*rate.PerSecond)
+		b.last = now
+	}
+}
+
+// rateLimiter drops records exceeding the limits and reports the amounts of dropped ones.
+type rateLimiter struct {
+	cfg      rateLimitConfig
+	out      slog.Handler   // Handler of the reports.
+	registry *levelRegistry // Levels of the logger: reports are skipped if WARN level is disabled.
+
+	mu           sync.Mutex
+	levels       map[slog.Level]*tokenBucket
+	attrs        map[string]*tokenBucket
+	total        RateLimitStats
+	levelDropped map[slog.Level]uint64 // Dropped since the last report.
+	attrDropped  map[string]uint64     // Dropped since the last report.
+	// Records are not limited after the limiter is stopped, as there is no one to report them.
+	closed bool
+
+	rep *reporter
+}
+
+// newRateLimiter returns a new rate limiter and starts its report goroutine. Reports are written to out
+// if WARN level is enabled by the base level of the registry.
+func newRateLimiter(cfg *rateLimitConfig, out slog.Handler, registry *levelRegistry) *rateLimiter {
+	now := time.Now()
+	l := &rateLimiter{
+		cfg:          *cfg,
+		out:          out,
+		registry:     registry,
+		levels:       make(map[slog.Level]*tokenBucket, len(cfg.levels)),
+		attrs:        make(map[string]*tokenBucket),
+		total:        RateLimitStats{ByLevel: make(map[slog.Level]uint64), ByAttr: make(map[string]uint64)},
+		levelDropped: make(map[slog.Level]uint64),
+		attrDropped:  make(map[string]uint64),
+	}
+	for level, rate := range cfg.levels {
+		l.levels[level] = &tokenBucket{tokens: float64(rate.Burst), last: now}
+	}
+	l.rep = startReporter(cfg.interval, func(bool) { l.report() })
+	return l
+}
+
+// allow implements recordFilter.
+func (l *rateLimiter) allow(r slog.Record, withAttrs []slog.Attr) bool {
+	value, limitAttr := l.attrValue(r, withAttrs)
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return true
+	}
+
+	var attrBucket *tokenBucket
+	if limitAttr {
+		attrBucket = l.attrs[value]
+		if attrBucket == nil {
+			attrBucket = &tokenBucket{tokens: float64(l.cfg.attrRate.Burst), last: now}
+			l.attrs[value] = attrBucket
+		}
+		attrBucket.refill(l.cfg.attrRate, now)
+		if attrBucket.tokens < 1 {
+			l.attrDropped[value]++
+			l.total.ByAttr[value]++
+			return false
+		}
+	}
+
+	levelBucket := l.levels[r.Level]
+	if levelBucket != nil {
+		levelBucket.refill(l.cfg.levels[r.Level], now)
+		if levelBucket.tokens < 1 {
+			l.levelDropped[r.Level]++
+			l.total.ByLevel[r.Level]++
+			return false
+		}
+		levelBucket.tokens--
+	}
+	// Tokens are taken only if all limits allow the record.
+	if attrBucket != nil {
+		attrBucket.tokens--
+	}
+	return true
+}
+
+// attrValue returns the value of the limited attribute. Record attributes take precedence over the logger ones.
+func (l *rateLimiter) attrValue(r slog.Record, withAttrs []slog.Attr) (string, bool) {
+	if l.cfg.attrKey == "" {
+		return "", false
+	}
+
+	var (
+		value string
+		found bool
+	)
+	for _, a := range withAttrs {
+		if a.Key == l.cfg.attrKey {
+			value, found = a.Value.Resolve().String(), true
+		}
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == l.cfg.attrKey {
+			value, found = a.Value.Resolve().String(), true
+		}
+		return true
+	})
+	return value, found
+}
+
+// stats returns a copy of the total amounts of dropped records.
+func (l *rateLimiter) stats() RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return RateLimitStats{ByLevel: maps.Clone(l.total.ByLevel), ByAttr: maps.Clone(l.total.ByAttr)}
+}
+
+// report writes the amounts of records dropped since the last report and removes idle attribute buckets.
+func (l *rateLimiter) report() {
+	now := time.Now()
+
+	l.mu.Lock()
+	levelDropped, attrDropped := l.levelDropped, l.attrDropped
+	l.levelDropped, l.attrDropped = make(map[slog.Level]uint64), make(map[string]uint64)
+	for value, b := range l.attrs {
+		// Full buckets are in the initial state, so they are recreated on demand.
+		if b.refill(l.cfg.attrRate, now); b.tokens >= float64(l.cfg.attrRate.Burst) {
+			delete(l.attrs, value)
+		}
+	}
+	l.mu.Unlock()
+
+	for _, level := range slices.Sorted(maps.Keys(levelDropped)) {
+		l.write(slog.String(LimitedLevelKey, levelName(level)), levelDropped[level])
+	}
+	values := slices.SortedFunc(maps.Keys(attrDropped), strings.Compare)
+	for _, value := range values {
+		l.write(slog.String(l.cfg.attrKey, value), attrDropped[value])
+	}
+}
+
+// write writes the report of the dropped records.
+func (l *rateLimiter) write(limited slog.Attr, dropped uint64) {
+	if LevelWarn < l.registry.level("") {
+		return
+	}
+	r := slog.NewRecord(time.Now(), LevelWarn, "records dropped by rate limiting", 0)
+	r.AddAttrs(limited, slog.Uint64(SuppressedKey, dropped))
+	// Errors are ignored, as slog.Logger does.
+	_ = l.out.Handle(context.Background(), r)
+}
+
+// close stops the rate limiter, reporting the records dropped in the last interval.
+func (l *rateLimiter) close(ctx context.Context) error {
+	return l.rep.close(ctx, func() {
+		l.mu.Lock()
+		l.closed = true
+		l.mu.Unlock()
+	})
+}
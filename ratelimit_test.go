@@ -0,0 +1,145 @@
+package logkit_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestRateLimit() {
+	ctx := context.Background()
+	// The rate is low enough for no tokens to be added during the test.
+	const slow = 0.001
+
+	s.Run("levels", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info"}),
+			logger.WithWriter(s.writer),
+			logger.WithRateLimit(map[slog.Level]logger.Rate{logger.LevelInfo: {PerSecond: slow, Burst: 3}}),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		for range 10 {
+			l.Info(ctx, "limited")
+			l.Warn(ctx, "not limited")
+		}
+		s.Require().Len(s.writer.arr, 3+10, "unexpected amount of logs received")
+		s.Require().Equal(logger.RateLimitStats{
+			ByLevel: map[slog.Level]uint64{logger.LevelInfo: 7},
+			ByAttr:  map[string]uint64{},
+		}, l.RateLimitStats(), "unexpected stats")
+
+		s.writer.CleanUp()
+		s.Require().NoError(l.Close(ctx), "got error, expected nil")
+		s.Require().Len(s.writer.arr, 1, "unexpected amount of reports")
+
+		var report map[string]any
+		s.Require().NoError(json.Unmarshal(s.writer.arr[0], &report), "failed to unmarshal log entry")
+		s.Require().Equal("WARN", report["level"], "unexpected report level")
+		s.Require().Equal("records dropped by rate limiting", report["msg"], "unexpected report message")
+		s.Require().Equal("INFO", report[logger.LimitedLevelKey], "unexpected limited level")
+		s.Require().Equal(float64(7), report[logger.SuppressedKey], "unexpected dropped amount")
+
+		s.writer.CleanUp()
+		for range 3 {
+			l.Info(ctx, "limited")
+		}
+		s.Require().Len(s.writer.arr, 3, "records must not be limited after close")
+	})
+
+	s.Run("attribute", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info"}),
+			logger.WithWriter(s.writer),
+			logger.WithRateLimitByAttr("tenant_id", logger.Rate{PerSecond: slow, Burst: 2}),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		noisy := logger.ContextWith(ctx, "tenant_id", "noisy")
+		for range 5 {
+			l.Info(noisy, "request")
+		}
+		for range 3 {
+			l.With("tenant_id", "quiet").Info(ctx, "request")
+			l.Info(ctx, "no tenant")
+		}
+		s.Require().Len(s.writer.arr, 2+2+3, "unexpected amount of logs received")
+		s.Require().Equal(map[string]uint64{"noisy": 3, "quiet": 1}, l.RateLimitStats().ByAttr, "unexpected stats")
+
+		s.writer.CleanUp()
+		s.Require().NoError(l.Close(ctx), "got error, expected nil")
+		s.Require().Len(s.writer.arr, 2, "unexpected amount of reports")
+		s.Require().Contains(string(s.writer.arr[0]), `"tenant_id":"noisy","suppressed":3`, "unexpected report")
+	})
+
+	s.Run("combined", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info"}),
+			logger.WithWriter(s.writer),
+			logger.WithRateLimit(map[slog.Level]logger.Rate{logger.LevelInfo: {PerSecond: slow, Burst: 1}}),
+			logger.WithRateLimitByAttr("tenant_id", logger.Rate{PerSecond: slow, Burst: 2}),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		tenant := logger.ContextWith(ctx, "tenant_id", "a")
+		l.Info(tenant, "allowed")
+		l.Info(tenant, "dropped by level")
+		// The tenant tokens are not taken by records dropped due to the level limit.
+		l.Warn(tenant, "allowed")
+		s.Require().Len(s.writer.arr, 2, "unexpected amount of logs received")
+		s.Require().NoError(l.Close(ctx), "got error, expected nil")
+	})
+
+	s.Run("reports above the level", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "error"}),
+			logger.WithWriter(s.writer),
+			logger.WithRateLimit(map[slog.Level]logger.Rate{logger.LevelError: {PerSecond: slow}}),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		l.Error(ctx, "allowed")
+		l.Error(ctx, "dropped")
+		s.Require().NoError(l.Close(ctx), "got error, expected nil")
+		s.Require().Len(s.writer.arr, 1, "reports must not be written with the level above WARN")
+		s.Require().Equal(map[slog.Level]uint64{logger.LevelError: 1}, l.RateLimitStats().ByLevel, "unexpected stats")
+	})
+
+	s.Run("periodic reports", func() {
+		w := newSyncWriter()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info"}),
+			logger.WithWriter(w),
+			logger.WithRateLimit(map[slog.Level]logger.Rate{logger.LevelInfo: {PerSecond: slow}}),
+			logger.WithRateLimitReportInterval(20*time.Millisecond),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+		defer func() { s.Require().NoError(l.Close(ctx), "got error, expected nil") }()
+
+		l.Info(ctx, "allowed")
+		l.Info(ctx, "dropped")
+		s.Require().Eventually(func() bool {
+			return w.contains(`"msg":"records dropped by rate limiting"`)
+		}, time.Second, 5*time.Millisecond, "dropped records must be reported on interval")
+	})
+
+	s.Run("invalid", func() {
+		for _, opt := range []logger.Option{
+			logger.WithRateLimit(map[slog.Level]logger.Rate{logger.LevelInfo: {PerSecond: -1}}),
+			logger.WithRateLimitByAttr("", logger.Rate{PerSecond: 1}),
+			logger.WithRateLimitByAttr("tenant_id", logger.Rate{Burst: -1}),
+			logger.WithRateLimitReportInterval(0),
+		} {
+			_, err := logger.NewLogger(opt)
+			s.Require().Error(err, "got nil, expected error")
+		}
+		s.Require().Equal(logger.RateLimitStats{}, logger.Default().RateLimitStats(), "unexpected stats")
+	})
+}
@@ -0,0 +1,161 @@
+package logkit
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// samplingConfig defines a configuration of the sampling.
+type samplingConfig struct {
+	interval   time.Duration
+	first      int
+	thereafter int
+	exempt     slog.Level // Minimum level of records which are never sampled.
+}
+
+// samplingOptions returns the sampling configuration, creating it if needed.
+// The sampling is enabled only once the interval is set via WithSampling.
+func (c *Config) samplingOptions() *samplingConfig {
+	if c.sampling == nil {
+		c.sampling = &samplingConfig{exempt: LevelError}
+	}
+	return c.sampling
+}
+
+// WithSampling enables sampling of records to reduce the volume of logs from hot paths.
+// Records are counted per level and message within each interval: the first ones are written,
+// after that only every thereafter-th one is. Zero thereafter suppresses all records after the first ones.
+//
+// Records with ERROR level or above are never sampled, see WithSamplingExemptLevel. At the end of each interval, a record with
+// the same level and message "records suppressed by sampling" is written for each level and message
+// with suppressed records, holding the message under SampledMessageKey and the amount under SuppressedKey.
+// The sampling is stopped on Logger.Close, reporting the records suppressed in the last interval;
+// records logged after that are not sampled.
+//
+// Example:
+//
+//	// Per second, the first 100 records with the same level and message are written, then every 10th one.
+//	logger, _ := NewLogger(WithSampling(time.Second, 100, 10))
+func WithSampling(interval time.Duration, first, thereafter int) Option {
+	return func(c *Config) error {
+		if interval <= 0 {
+			return errors.New("sampling interval must be positive")
+		}
+		if first < 0 || thereafter < 0 {
+			return errors.New("sampling counts must not be negative")
+		}
+		cfg := c.samplingOptions()
+		cfg.interval, cfg.first, cfg.thereafter = interval, first, thereafter
+		return nil
+	}
+}
+
+// WithSamplingExemptLevel sets the minimum level of records which are never sampled, LevelError by default.
+// It has effect only along with WithSampling.
+//
+// Example:
+//
+//	// WARN records are written in full too.
+//	logger, _ := NewLogger(WithSampling(time.Second, 100, 10), WithSamplingExemptLevel(LevelWarn))
+func WithSamplingExemptLevel(level slog.Level) Option {
+	return func(c *Config) error {
+		c.samplingOptions().exempt = level
+		return nil
+	}
+}
+
+// samplingKey identifies records counted together.
+type samplingKey struct {
+	level slog.Level
+	msg   string
+}
+
+// samplingCounter holds the counts of records within the current interval.
+type samplingCounter struct {
+	seen       int
+	suppressed int
+}
+
+// sampler counts records and decides which of them are written.
+type sampler struct {
+	cfg    samplingConfig
+	out    slog.Handler // Handler of the suppression reports.
+	mu     sync.Mutex
+	counts map[samplingKey]*samplingCounter
+	closed bool // Records are not sampled after the sampler is stopped, as there is no one to report them.
+	rep    *reporter
+}
+
+// newSampler returns a new sampler and starts its interval goroutine. Reports are written to out.
+func newSampler(cfg *samplingConfig, out slog.Handler) *sampler {
+	s := &sampler{cfg: *cfg, out: out, counts: make(map[samplingKey]*samplingCounter)}
+	// Counts are reset at the end of each interval.
+	s.rep = startReporter(cfg.interval, func(bool) { s.report() })
+	return s
+}
+
+// allow implements recordFilter: it counts the record and reports whether it must be written.
+func (s *sampler) allow(r slog.Record, _ []slog.Attr) bool {
+	if r.Level >= s.cfg.exempt {
+		return true
+	}
+
+	key := samplingKey{r.Level, r.Message}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return true
+	}
+	c, ok := s.counts[key]
+	if !ok {
+		c = &samplingCounter{}
+		s.counts[key] = c
+	}
+	c.seen++
+	if c.seen <= s.cfg.first || (s.cfg.thereafter > 0 && (c.seen-s.cfg.first)%s.cfg.thereafter == 0) {
+		return true
+	}
+	c.suppressed++
+	return false
+}
+
+// report resets the counts and writes the amounts of suppressed records, ordered by level and message.
+func (s *sampler) report() {
+	s.mu.Lock()
+	counts := s.counts
+	s.counts = make(map[samplingKey]*samplingCounter, len(counts))
+	s.mu.Unlock()
+
+	keys := make([]samplingKey, 0, len(counts))
+	for k, c := range counts {
+		if c.suppressed > 0 {
+			keys = append(keys, k)
+		}
+	}
+	slices.SortFunc(keys, func(a, b samplingKey) int {
+		return cmp.Or(cmp.Compare(a.level, b.level), strings.Compare(a.msg, b.msg))
+	})
+
+	for _, k := range keys {
+		r := slog.NewRecord(time.Now(), k.level, "records suppressed by sampling", 0)
+		r.AddAttrs(slog.String(SampledMessageKey, k.msg), slog.Int(SuppressedKey, counts[k].suppressed))
+		// Errors are ignored, as slog.Logger does.
+		_ = s.out.Handle(context.Background(), r)
+	}
+}
+
+// close stops the sampler, reporting the records suppressed in the last interval.
+func (s *sampler) close(ctx context.Context) error {
+	return s.rep.close(ctx, func() {
+		s.mu.Lock()
+		s.closed = true
+		s.mu.Unlock()
+	})
+}
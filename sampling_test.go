@@ -0,0 +1,109 @@
+package logkit_test
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestSampling() {
+	ctx := context.Background()
+
+	s.Run("first and thereafter", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info"}),
+			logger.WithWriter(s.writer),
+			logger.WithSampling(time.Hour, 2, 3),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		for i := range 10 {
+			l.Debug(ctx, "filtered")
+			l.Info(ctx, "hot", "i", i)
+			l.Error(ctx, "hot")
+		}
+		l.Warn(ctx, "cold")
+
+		var written []int
+		for _, data := range s.writer.arr {
+			var entry struct {
+				logEntry
+				I *int `json:"i"`
+			}
+			s.Require().NoError(json.Unmarshal(data, &entry), "failed to unmarshal log entry")
+			if entry.I != nil {
+				written = append(written, *entry.I)
+			}
+		}
+		s.Require().Equal([]int{0, 1, 4, 7}, written, "unexpected sampled records")
+		s.Require().Len(s.writer.arr, 4+10+1, "errors must not be sampled")
+
+		s.writer.CleanUp()
+		s.Require().NoError(l.Close(ctx), "got error, expected nil")
+		s.Require().Len(s.writer.arr, 1, "unexpected amount of reports")
+
+		var report map[string]any
+		s.Require().NoError(json.Unmarshal(s.writer.arr[0], &report), "failed to unmarshal log entry")
+		s.Require().Equal("INFO", report["level"], "unexpected report level")
+		s.Require().Equal("records suppressed by sampling", report["msg"], "unexpected report message")
+		s.Require().Equal("hot", report[logger.SampledMessageKey], "unexpected sampled message")
+		s.Require().Equal(float64(6), report[logger.SuppressedKey], "unexpected suppressed amount")
+
+		s.writer.CleanUp()
+		for range 3 {
+			l.Info(ctx, "hot")
+		}
+		s.Require().Len(s.writer.arr, 3, "records must not be sampled after close")
+	})
+
+	s.Run("interval", func() {
+		w := newSyncWriter()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info"}),
+			logger.WithWriter(w),
+			logger.WithSampling(20*time.Millisecond, 1, 0),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+		defer func() { s.Require().NoError(l.Close(ctx), "got error, expected nil") }()
+
+		l.Info(ctx, "tick")
+		l.Info(ctx, "tick")
+		s.Require().Eventually(func() bool {
+			return w.contains(`"suppressed":1`)
+		}, time.Second, 5*time.Millisecond, "suppressed records must be reported on interval")
+
+		// Counts are reset after the interval.
+		l.Info(ctx, "tick")
+		s.Require().Eventually(func() bool {
+			return len(w.lines()) == 3
+		}, time.Second, 5*time.Millisecond, "records must be written in the next interval")
+	})
+
+	s.Run("exempt level", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info"}),
+			logger.WithWriter(s.writer),
+			logger.WithSamplingExemptLevel(logger.LevelWarn),
+			logger.WithSampling(time.Hour, 1, 0),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+		defer func() { s.Require().NoError(l.Close(ctx), "got error, expected nil") }()
+
+		for range 3 {
+			l.Info(ctx, "sampled")
+			l.Warn(ctx, "exempt")
+		}
+		s.Require().Len(s.writer.arr, 1+3, "records at the exempt level must not be sampled")
+	})
+
+	s.Run("invalid", func() {
+		_, err := logger.NewLogger(logger.WithSampling(0, 1, 1))
+		s.Require().Error(err, "got nil, expected error")
+		_, err = logger.NewLogger(logger.WithSampling(time.Second, -1, 1))
+		s.Require().Error(err, "got nil, expected error")
+	})
+}
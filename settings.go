@@ -0,0 +1,136 @@
+package logkit
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// Settings is a typed form of the configuration described in WithConfig.
+// Tags allow decoding it from JSON and YAML files and documenting the environment variables read by WithEnv:
+// the env tag is a variable name without the prefix, e.g. APP_LOG_LEVEL for "APP" prefix.
+type Settings struct {
+	Format          string            `json:"format,omitempty"           yaml:"format,omitempty"           env:"LOG_FORMAT"`
+	Level           string            `json:"level,omitempty"            yaml:"level,omitempty"            env:"LOG_LEVEL"`
+	TimeTemplate    string            `json:"time_template,omitempty"    yaml:"time_template,omitempty"    env:"LOG_TIME_TEMPLATE"`
+	LogStream       string            `json:"log_stream,omitempty"       yaml:"log_stream,omitempty"       env:"LOG_STREAM"`
+	Levels          map[string]string `json:"levels,omitempty"           yaml:"levels,omitempty"           env:"LOG_LEVELS"`
+	FilePath        string            `json:"file_path,omitempty"        yaml:"file_path,omitempty"        env:"LOG_FILE_PATH"`
+	MaxSizeMB       int               `json:"max_size_mb,omitempty"      yaml:"max_size_mb,omitempty"      env:"LOG_MAX_SIZE_MB"`
+	MaxAge          string            `json:"max_age,omitempty"          yaml:"max_age,omitempty"          env:"LOG_MAX_AGE"`
+	MaxBackups      int               `json:"max_backups,omitempty"      yaml:"max_backups,omitempty"      env:"LOG_MAX_BACKUPS"`
+	Rotation        string            `json:"rotation,omitempty"         yaml:"rotation,omitempty"         env:"LOG_ROTATION"`
+	Compress        bool              `json:"compress,omitempty"         yaml:"compress,omitempty"         env:"LOG_COMPRESS"`
+	AddSource       bool              `json:"add_source,omitempty"       yaml:"add_source,omitempty"       env:"LOG_ADD_SOURCE"`
+	SourcePath      string            `json:"source_path,omitempty"      yaml:"source_path,omitempty"      env:"LOG_SOURCE_PATH"`
+	StacktraceLevel string            `json:"stacktrace_level,omitempty" yaml:"stacktrace_level,omitempty" env:"LOG_STACKTRACE_LEVEL"`
+	DedupWindow     string            `json:"dedup_window,omitempty"     yaml:"dedup_window,omitempty"     env:"LOG_DEDUP_WINDOW"`
+	DedupAttrs      string            `json:"dedup_attrs,omitempty"      yaml:"dedup_attrs,omitempty"      env:"LOG_DEDUP_ATTRS"`
+}
+
+// WithSettings applies the typed configuration. Zero fields are treated as unset, so they keep the values
+// applied by previous options. Values are validated the same way as WithConfig does.
+func WithSettings(s Settings) Option {
+	return func(c *Config) error {
+		return c.applyConfig(s.toConfig(), &ConfigError{})
+	}
+}
+
+// Settings returns the effective configuration of the logger, with defaults applied.
+// Levels reflect changes made at runtime, e.g. via SetLevel. LogStream is empty for writers set via WithWriter.
+func (logg Logger) Settings() Settings {
+	var s Settings
+	if logg.settings != nil {
+		s = *logg.settings.Load()
+	}
+	if logg.levels != nil {
+		s.Level = strings.ToLower(levelName(logg.levels.level("")))
+		s.Levels = componentLevelNames(logg.levels.components())
+	}
+	return s
+}
+
+// toConfig converts the settings to the config map accepted by WithConfig, skipping zero fields.
+func (s Settings) toConfig() map[string]any {
+	cfg := make(map[string]any)
+	setString := func(field, v string) {
+		if v != "" {
+			cfg[field] = v
+		}
+	}
+
+	setString("format", s.Format)
+	setString("level", s.Level)
+	setString("time_template", s.TimeTemplate)
+	setString("log_stream", s.LogStream)
+	if len(s.Levels) > 0 {
+		levels := make(map[string]any, len(s.Levels))
+		for name, level := range s.Levels {
+			levels[name] = level
+		}
+		cfg["levels"] = levels
+	}
+	setString("file_path", s.FilePath)
+	if s.MaxSizeMB != 0 {
+		cfg["max_size_mb"] = s.MaxSizeMB
+	}
+	setString("max_age", s.MaxAge)
+	if s.MaxBackups != 0 {
+		cfg["max_backups"] = s.MaxBackups
+	}
+	setString("rotation", s.Rotation)
+	if s.Compress {
+		cfg["compress"] = true
+	}
+	if s.AddSource {
+		cfg["add_source"] = true
+	}
+	setString("source_path", s.SourcePath)
+	setString("stacktrace_level", s.StacktraceLevel)
+	setString("dedup_window", s.DedupWindow)
+	setString("dedup_attrs", s.DedupAttrs)
+
+	return cfg
+}
+
+// settingsFromConfig returns the settings corresponding to the applied configuration.
+func settingsFromConfig(c *Config) *Settings {
+	s := &Settings{
+		Format:       c.logType,
+		Level:        strings.ToLower(levelName(c.level)),
+		TimeTemplate: c.timeTemplate,
+		LogStream:    c.logStream,
+		AddSource:    c.addSource,
+		Levels:       componentLevelNames(c.componentLevels),
+		SourcePath:   c.sourcePath,
+	}
+	if c.logStream == "file" {
+		s.FilePath = c.fileOpts.Path
+		s.MaxSizeMB = c.fileOpts.MaxSizeMB
+		if c.fileOpts.MaxAge > 0 {
+			s.MaxAge = c.fileOpts.MaxAge.String()
+		}
+		s.MaxBackups = c.fileOpts.MaxBackups
+		s.Rotation = c.fileOpts.Rotation
+		s.Compress = c.fileOpts.Compress
+	}
+	if c.stacktrace {
+		s.StacktraceLevel = strings.ToLower(levelName(c.stacktraceLevel))
+	}
+	if c.dedup.window > 0 {
+		s.DedupWindow = c.dedup.window.String()
+		s.DedupAttrs = strings.Join(c.dedup.attrs, ",")
+	}
+	return s
+}
+
+// componentLevelNames converts component levels to their lowercase names. Nil is returned for empty levels.
+func componentLevelNames(levels map[string]slog.Level) map[string]string {
+	if len(levels) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(levels))
+	for name, level := range levels {
+		res[name] = strings.ToLower(levelName(level))
+	}
+	return res
+}
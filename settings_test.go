@@ -0,0 +1,109 @@
+package logkit_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestSettings() {
+	s.Run("defaults", func() {
+		l, err := logger.NewLogger()
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().Equal(logger.Settings{
+			Format:       logger.DefaultLogType,
+			Level:        logger.DefaultLevel,
+			TimeTemplate: logger.DefaultTimeTemplate,
+			LogStream:    logger.DefaultWriter,
+			SourcePath:   logger.SourcePathFull,
+		}, l.Settings(), "unexpected settings")
+	})
+
+	s.Run("applied", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithSettings(logger.Settings{
+				Format:          "logfmt",
+				Level:           "debug",
+				TimeTemplate:    time.DateTime,
+				Levels:          map[string]string{"db": "trace"},
+				StacktraceLevel: "error",
+			}),
+			logger.WithWriter(s.writer),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		l.Named("db").Trace(context.Background(), "trace")
+		s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+		s.Require().Contains(string(s.writer.arr[0]), "level=TRACE msg=trace", "unexpected log entry")
+
+		s.Require().NoError(l.SetLevel("warn"), "got error, expected nil")
+		s.Require().NoError(l.Named("http").SetLevel("info"), "got error, expected nil")
+		s.Require().Equal(logger.Settings{
+			Format:          "logfmt",
+			Level:           "warn",
+			TimeTemplate:    time.DateTime,
+			Levels:          map[string]string{"db": "trace", "http": "info"},
+			SourcePath:      logger.SourcePathFull,
+			StacktraceLevel: "error",
+		}, l.Settings(), "unexpected settings")
+	})
+
+	s.Run("file", func() {
+		path := filepath.Join(s.T().TempDir(), "app.log")
+		l, err := logger.NewLogger(logger.WithSettings(logger.Settings{
+			LogStream:  "file",
+			FilePath:   path,
+			MaxAge:     "24h",
+			MaxBackups: 2,
+			Compress:   true,
+		}))
+		s.Require().NoError(err, "got error, expected nil")
+		defer func() { _ = l.Close(context.Background()) }()
+
+		settings := l.Settings()
+		s.Require().Equal("file", settings.LogStream, "unexpected log stream")
+		s.Require().Equal(path, settings.FilePath, "unexpected file path")
+		s.Require().Equal("24h0m0s", settings.MaxAge, "unexpected max age")
+		s.Require().Equal(2, settings.MaxBackups, "unexpected max backups")
+		s.Require().True(settings.Compress, "unexpected compress")
+
+		// The effective settings are accepted back as the config.
+		data, err := json.Marshal(settings)
+		s.Require().NoError(err, "got error, expected nil")
+		var cfg map[string]any
+		s.Require().NoError(json.Unmarshal(data, &cfg), "got error, expected nil")
+		cfg["file_path"] = filepath.Join(s.T().TempDir(), "copy.log")
+		l2, err := logger.NewLogger(logger.WithConfig(cfg))
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().NoError(l2.Close(context.Background()), "got error, expected nil")
+	})
+
+	s.Run("custom writer", func() {
+		l, err := logger.NewLogger(logger.WithWriter(s.writer))
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().Empty(l.Settings().LogStream, "unexpected log stream")
+	})
+
+	s.Run("invalid", func() {
+		_, err := logger.NewLogger(logger.WithSettings(logger.Settings{Level: "loud", SourcePath: "relative"}))
+		s.Require().ErrorContains(err, "invalid_value=level,source_path", "unexpected error")
+	})
+
+	s.Run("tags", func() {
+		t := reflect.TypeFor[logger.Settings]()
+		for i := range t.NumField() {
+			f := t.Field(i)
+			field, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			yamlField, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+			s.Require().Equal(field, yamlField, "json and yaml tags differ for %s", f.Name)
+			s.Require().Equal("LOG_"+strings.ToUpper(strings.TrimPrefix(field, "log_")), f.Tag.Get("env"),
+				"unexpected env tag for %s", f.Name)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package logkit
+
+import (
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// Supported values of the "source_path" config field.
+const (
+	// SourcePathFull keeps the full path of the source file.
+	SourcePathFull = "full"
+	// SourcePathModule trims the path to the form relative to the module root, e.g. "internal/app/app.go".
+	SourcePathModule = "module"
+	// SourcePathFile keeps only the file name, e.g. "app.go".
+	SourcePathFile = "file"
+)
+
+// WithSource enables capturing the source location of the logging call: file, line and function.
+// It is the same as setting "add_source" config field to true.
+func WithSource() Option {
+	return func(c *Config) error {
+		c.addSource = true
+		c.handler = buildHandler(c)
+		return nil
+	}
+}
+
+// WithCallerSkip sets the number of additional stack frames to skip when capturing the source location.
+// It is useful when the logger is wrapped by helper functions: WithCallerSkip(1) reports the caller
+// of the helper instead of the helper itself.
+func WithCallerSkip(skip int) Option {
+	return func(c *Config) error {
+		if skip < 0 {
+			return errors.New("caller skip must not be negative")
+		}
+		c.callerSkip = skip
+		return nil
+	}
+}
+
+// replaceSourceAttr trims the path of the source file according to the mode.
+func replaceSourceAttr(groups []string, a slog.Attr, mode string) slog.Attr {
+	if a.Key != slog.SourceKey || len(groups) != 0 {
+		return a
+	}
+	src, ok := a.Value.Any().(*slog.Source)
+	if !ok || src == nil {
+		return a
+	}
+
+	trimmed := *src
+	switch mode {
+	case SourcePathModule:
+		trimmed.File = moduleRelativePath(src.Function, src.File)
+	case SourcePathFile:
+		trimmed.File = filepath.Base(src.File)
+	default:
+		return a
+	}
+	return slog.Any(a.Key, &trimmed)
+}
+
+// modulePaths is a lazily loaded list of paths of the main module and its dependencies.
+var modulePaths = sync.OnceValue(func() []string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+
+	paths := make([]string, 0, len(info.Deps)+1)
+	if info.Main.Path != "" {
+		paths = append(paths, info.Main.Path)
+	}
+	for _, dep := range info.Deps {
+		paths = append(paths, dep.Path)
+	}
+	return paths
+})
+
+// moduleRelativePath returns the path of the file relative to the root of the module it belongs to.
+// The module is detected by the package of the function. If it is unknown, e.g. for standard library
+// or main packages, the path relative to the import path of the package is returned.
+func moduleRelativePath(function, file string) string {
+	// External test packages are located in the directory of the tested package.
+	pkgPath := strings.TrimSuffix(packagePath(function), "_test")
+	name := filepath.Base(file)
+
+	modPath := ""
+	for _, p := range modulePaths() {
+		if (pkgPath == p || strings.HasPrefix(pkgPath, p+"/")) && len(p) > len(modPath) {
+			modPath = p
+		}
+	}
+
+	switch {
+	case modPath != "":
+		return strings.TrimPrefix(strings.TrimPrefix(pkgPath, modPath)+"/"+name, "/")
+	case pkgPath == "main" || pkgPath == "":
+		return name
+	default:
+		return pkgPath + "/" + name
+	}
+}
+
+// packagePath returns the import path of the package the function belongs to,
+// e.g. "github.com/Averlex/logkit" for "github.com/Averlex/logkit.(*Logger).Info".
+func packagePath(function string) string {
+	lastSlash := strings.LastIndexByte(function, '/')
+	dot := strings.IndexByte(function[lastSlash+1:], '.')
+	if dot < 0 {
+		return function
+	}
+	return function[:lastSlash+1+dot]
+}
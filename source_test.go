@@ -0,0 +1,112 @@
+package logkit_test
+
+import (
+	"context"
+	"encoding/json"
+	"runtime"
+	"strings"
+
+	logger "github.com/Averlex/logkit"
+)
+
+type sourceEntry struct {
+	Source struct {
+		Function string `json:"function"`
+		File     string `json:"file"`
+		Line     int    `json:"line"`
+	} `json:"source"`
+}
+
+// logViaHelper imitates a user helper wrapping the logger.
+func logViaHelper(l *logger.Logger, msg string) {
+	l.Info(context.Background(), msg)
+}
+
+// currentLine returns the line of the caller.
+func currentLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func (s *LoggerTestSuite) TestSource() {
+	testCases := []struct {
+		name         string
+		config       map[string]any
+		opts         []logger.Option
+		viaHelper    bool
+		expectedFile string
+	}{
+		{
+			name:         "config full path",
+			config:       map[string]any{"add_source": true},
+			expectedFile: "/source_test.go",
+		},
+		{
+			name:         "option module path",
+			config:       map[string]any{"source_path": "module"},
+			opts:         []logger.Option{logger.WithSource()},
+			expectedFile: "source_test.go",
+		},
+		{
+			name:         "file name",
+			config:       map[string]any{"add_source": true, "source_path": "file"},
+			expectedFile: "source_test.go",
+		},
+		{
+			name:         "caller skip",
+			config:       map[string]any{"add_source": true, "source_path": "file"},
+			opts:         []logger.Option{logger.WithCallerSkip(1)},
+			viaHelper:    true,
+			expectedFile: "source_test.go",
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			s.writer.CleanUp()
+			tC.config["level"] = "info"
+			opts := append([]logger.Option{logger.WithConfig(tC.config), logger.WithWriter(s.writer)}, tC.opts...)
+			l, err := logger.NewLogger(opts...)
+			s.Require().NoError(err, "got error, expected nil")
+
+			var line int
+			if tC.viaHelper {
+				line = currentLine() + 1
+				logViaHelper(l, "source")
+			} else {
+				line = currentLine() + 1
+				l.Info(context.Background(), "source")
+			}
+			s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+
+			var entry sourceEntry
+			s.Require().NoError(json.Unmarshal(s.writer.arr[0], &entry), "failed to unmarshal log entry")
+			if strings.HasPrefix(tC.expectedFile, "/") {
+				s.Require().True(strings.HasSuffix(entry.Source.File, tC.expectedFile), "unexpected file %q", entry.Source.File)
+			} else {
+				s.Require().Equal(tC.expectedFile, entry.Source.File, "unexpected file")
+			}
+			s.Require().Equal(line, entry.Source.Line, "unexpected line")
+			s.Require().Contains(entry.Source.Function, "TestSource", "unexpected function")
+		})
+	}
+
+	s.Run("disabled", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(logger.WithConfig(map[string]any{"level": "info"}), logger.WithWriter(s.writer))
+		s.Require().NoError(err, "got error, expected nil")
+
+		l.Info(context.Background(), "no source")
+		s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+		s.Require().NotContains(string(s.writer.arr[0]), `"source"`, "unexpected source")
+	})
+
+	s.Run("invalid options", func() {
+		_, err := logger.NewLogger(logger.WithConfig(map[string]any{"source_path": "relative"}))
+		s.Require().Error(err, "got nil, expected error")
+		_, err = logger.NewLogger(logger.WithConfig(map[string]any{"add_source": "true"}))
+		s.Require().Error(err, "got nil, expected error")
+		_, err = logger.NewLogger(logger.WithCallerSkip(-1))
+		s.Require().Error(err, "got nil, expected error")
+	})
+}
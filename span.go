@@ -0,0 +1,113 @@
+package logkit
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SeverityKey is a key of the span event attribute holding the level of the record, see WithSpanEvents.
+const SeverityKey = "log.severity"
+
+// WithSpanEvents enables recording of the records with the given level or above as events of the active span
+// in the context passed to the logging method. The event is named after the message, its attributes are:
+//   - log.severity: the level name, see SeverityKey.
+//   - attributes of the record and of the logger (added via With), groups are flattened with dots.
+//
+// Records with ERROR level or above also set the span status to error with the message as its description.
+//
+// Only records passing the level of the logger are recorded. Spans which are not recording are ignored,
+// so the option has no effect without the OpenTelemetry SDK configured.
+func WithSpanEvents(level slog.Level) Option {
+	return func(c *Config) error {
+		c.spanEvents = true
+		c.spanEventsLevel = level
+		return nil
+	}
+}
+
+// addSpanEvent records the record as an event of the span in ctx.
+func (logg Logger) addSpanEvent(ctx context.Context, r slog.Record) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(logg.attrs)+r.NumAttrs()+1)
+	attrs = append(attrs, attribute.String(SeverityKey, levelName(r.Level)))
+	for _, a := range logg.attrs {
+		attrs = appendSpanAttrs(attrs, a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = appendSpanAttrs(attrs, a)
+		return true
+	})
+
+	span.AddEvent(r.Message, trace.WithTimestamp(r.Time), trace.WithAttributes(attrs...))
+	if r.Level >= LevelError {
+		span.SetStatus(codes.Error, r.Message)
+	}
+}
+
+// appendSpanAttrs converts the slog attribute to OpenTelemetry ones. Groups are flattened with dots.
+func appendSpanAttrs(attrs []attribute.KeyValue, a slog.Attr) []attribute.KeyValue {
+	flattenAttr(nil, "", nil, a, func(key string, v slog.Value) {
+		attrs = append(attrs, spanAttr(key, v))
+	})
+	return attrs
+}
+
+// spanAttr converts the resolved slog value to OpenTelemetry attribute.
+func spanAttr(key string, v slog.Value) attribute.KeyValue {
+	switch v.Kind() {
+	case slog.KindString:
+		return attribute.String(key, v.String())
+	case slog.KindInt64:
+		return attribute.Int64(key, v.Int64())
+	case slog.KindUint64:
+		if u := v.Uint64(); u <= 1<<63-1 {
+			return attribute.Int64(key, int64(u))
+		}
+		return attribute.String(key, v.String())
+	case slog.KindFloat64:
+		return attribute.Float64(key, v.Float64())
+	case slog.KindBool:
+		return attribute.Bool(key, v.Bool())
+	case slog.KindDuration:
+		return attribute.String(key, v.Duration().String())
+	case slog.KindTime:
+		return attribute.String(key, v.Time().Format(time.RFC3339Nano))
+	}
+
+	switch val := v.Any().(type) {
+	case []string:
+		return attribute.StringSlice(key, val)
+	case []int64:
+		return attribute.Int64Slice(key, val)
+	case []int:
+		return attribute.IntSlice(key, val)
+	case []float64:
+		return attribute.Float64Slice(key, val)
+	case []bool:
+		return attribute.BoolSlice(key, val)
+	default:
+		return attribute.String(key, anyToString(val))
+	}
+}
+
+// withSpanAttrs returns attrs with the logging arguments added, for loggers with span events enabled.
+func withSpanAttrs(attrs []slog.Attr, args []any) []slog.Attr {
+	var r slog.Record
+	r.Add(args...)
+	attrs = slices.Clip(attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return attrs
+}
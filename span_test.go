@@ -0,0 +1,92 @@
+package logkit_test
+
+import (
+	"context"
+	"errors"
+
+	logger "github.com/Averlex/logkit"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func (s *LoggerTestSuite) TestSpanEvents() {
+	newSpan := func() (context.Context, func() sdktrace.ReadOnlySpan) {
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		ctx, span := provider.Tracer("test").Start(context.Background(), "operation")
+		return ctx, func() sdktrace.ReadOnlySpan {
+			span.End()
+			spans := recorder.Ended()
+			s.Require().Len(spans, 1, "unexpected amount of spans")
+			return spans[0]
+		}
+	}
+
+	s.Run("events", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "debug"}),
+			logger.WithWriter(s.writer),
+			logger.WithSpanEvents(logger.LevelInfo),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		ctx, end := newSpan()
+		l = l.With("component", "db")
+		l.Debug(ctx, "too verbose")
+		l.Info(ctx, "query executed", "rows", 3, "cached", true, "request", map[string]any{"id": 1})
+		l.Warn(ctx, "slow query", "params", []string{"a", "b"})
+		span := end()
+
+		s.Require().Len(s.writer.arr, 3, "records must be written regardless of span events")
+		events := span.Events()
+		s.Require().Len(events, 2, "unexpected amount of events")
+
+		s.Require().Equal("query executed", events[0].Name, "unexpected event name")
+		s.Require().Equal([]attribute.KeyValue{
+			attribute.String("log.severity", "INFO"),
+			attribute.String("component", "db"),
+			attribute.Int64("rows", 3),
+			attribute.Bool("cached", true),
+			attribute.String("request", "map[id:1]"),
+		}, events[0].Attributes, "unexpected event attributes")
+
+		s.Require().Equal("slow query", events[1].Name, "unexpected event name")
+		s.Require().Contains(events[1].Attributes, attribute.StringSlice("params", []string{"a", "b"}),
+			"unexpected event attributes")
+		s.Require().Equal(codes.Unset, span.Status().Code, "unexpected span status")
+	})
+
+	s.Run("error status", func() {
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info"}),
+			logger.WithWriter(s.writer),
+			logger.WithSpanEvents(logger.LevelWarn),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		ctx, end := newSpan()
+		l.Error(ctx, "request failed", errors.New("boom"))
+		span := end()
+
+		s.Require().Equal(codes.Error, span.Status().Code, "unexpected span status")
+		s.Require().Equal("request failed", span.Status().Description, "unexpected span status description")
+		s.Require().Len(span.Events(), 1, "unexpected amount of events")
+		s.Require().Contains(span.Events()[0].Attributes, attribute.String("error.msg", "boom"),
+			"unexpected event attributes")
+	})
+
+	s.Run("disabled", func() {
+		l, err := logger.NewLogger(logger.WithConfig(map[string]any{"level": "info"}), logger.WithWriter(s.writer))
+		s.Require().NoError(err, "got error, expected nil")
+
+		ctx, end := newSpan()
+		l.Error(ctx, "not recorded")
+		span := end()
+
+		s.Require().Empty(span.Events(), "unexpected events")
+		s.Require().Equal(codes.Unset, span.Status().Code, "unexpected span status")
+	})
+}
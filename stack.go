@@ -0,0 +1,84 @@
+package logkit
+
+import (
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// maxStackDepth is a maximum number of frames captured in a stack trace.
+const maxStackDepth = 64
+
+// StackFrame is a single frame of a stack trace.
+type StackFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// Stack is a stack trace, the innermost frame goes first.
+// It is written as an array of frames in JSON and as an indented multi-line block in other formats:
+//
+//	main.run
+//		/app/main.go:42
+//	main.main
+//		/app/main.go:10
+type Stack []StackFrame
+
+// String returns the stack trace as a multi-line block.
+func (s Stack) String() string {
+	var b strings.Builder
+	for i, f := range s {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(f.Function)
+		b.WriteString("\n\t")
+		b.WriteString(f.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(f.Line))
+	}
+	return b.String()
+}
+
+// WithStacktrace enables capturing the stack trace for records with the given level or above.
+// The trace is added under StackKey. It is the same as setting "stacktrace_level" config field.
+//
+// The stack trace is captured only for records which are enabled by the logger level,
+// so records kept in the buffer of ContextWithBuffer have no stack trace.
+func WithStacktrace(level slog.Level) Option {
+	return func(c *Config) error {
+		c.stacktrace = true
+		c.stacktraceLevel = level
+		return nil
+	}
+}
+
+// captureStack returns the stack trace of the calling goroutine, skipping the given number of frames
+// in the same way as runtime.Callers does.
+func captureStack(skip int) Stack {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip+1, pcs[:])
+	return stackFromPCs(pcs[:n])
+}
+
+// stackFromPCs converts program counters to a stack trace.
+func stackFromPCs(pcs []uintptr) Stack {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	stack := make(Stack, 0, len(pcs))
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		if f.Function != "" || f.File != "" {
+			stack = append(stack, StackFrame{Function: f.Function, File: f.File, Line: f.Line})
+		}
+		if !more {
+			break
+		}
+	}
+	return stack
+}
@@ -0,0 +1,64 @@
+package logkit_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestStacktrace() {
+	s.Run("json", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info", "stacktrace_level": "error"}),
+			logger.WithWriter(s.writer),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		l.Warn(context.Background(), "no stack")
+		l.Error(context.Background(), "stack")
+		s.Require().Len(s.writer.arr, 2, "unexpected amount of logs received")
+
+		var entry struct {
+			Stack []logger.StackFrame `json:"stack"`
+		}
+		s.Require().NoError(json.Unmarshal(s.writer.arr[0], &entry), "failed to unmarshal log entry")
+		s.Require().Empty(entry.Stack, "unexpected stack trace")
+
+		s.Require().NoError(json.Unmarshal(s.writer.arr[1], &entry), "failed to unmarshal log entry")
+		s.Require().NotEmpty(entry.Stack, "expected stack trace")
+		s.Require().Contains(entry.Stack[0].Function, "TestStacktrace", "stack trace must start with the caller")
+		s.Require().True(strings.HasSuffix(entry.Stack[0].File, "stack_test.go"), "unexpected file")
+		s.Require().Positive(entry.Stack[0].Line, "unexpected line")
+	})
+
+	s.Run("text", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"format": "text", "level": "info"}),
+			logger.WithStacktrace(logger.LevelWarn),
+			logger.WithWriter(s.writer),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+
+		l.Info(context.Background(), "plain")
+		l.Warn(context.Background(), "stack", "id", 1)
+		s.Require().Len(s.writer.arr, 3, "unexpected amount of writes")
+		s.Require().NotContains(string(s.writer.arr[0]), "stack", "unexpected stack trace")
+		s.Require().Contains(string(s.writer.arr[0]), "msg=plain\n", "unexpected record line")
+		s.Require().Contains(string(s.writer.arr[1]), "msg=stack id=1\n", "unexpected record line")
+
+		lines := strings.Split(strings.TrimSuffix(string(s.writer.arr[2]), "\n"), "\n")
+		s.Require().Equal("    stack:", lines[0], "unexpected stack trace header")
+		s.Require().Contains(lines[1], "TestStacktrace", "stack trace must start with the caller")
+		s.Require().True(strings.HasPrefix(lines[2], "        \t"), "unexpected indentation")
+		s.Require().Contains(lines[2], "stack_test.go:", "unexpected file")
+	})
+
+	s.Run("invalid level", func() {
+		_, err := logger.NewLogger(logger.WithConfig(map[string]any{"stacktrace_level": "critical"}))
+		s.Require().Error(err, "got nil, expected error")
+	})
+}
@@ -0,0 +1,117 @@
+package logkit
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// tailBufferKey is the context key of the buffer created via ContextWithBuffer.
+type tailBufferKey struct{}
+
+// ContextWithBuffer returns a copy of ctx carrying a request-scoped buffer of up to size records
+// and a function discarding the buffer, which should be called when the request ends.
+//
+// TRACE, DEBUG and VERBOSE records logged with the returned context, or contexts derived from it, which are
+// disabled by the logger level are kept in the buffer instead of being dropped. They are written once
+// a record with ERROR level or above is logged with the same context, right before it, or when FlushBuffer
+// is called. After that, such records are written immediately until the request ends, so failing requests
+// are logged in full detail, while healthy ones cost only the enabled records. If the buffer is full,
+// the oldest records are dropped.
+//
+// Example:
+//
+//	ctx, end := logkit.ContextWithBuffer(r.Context(), 1000)
+//	defer end()
+//	logger.Debug(ctx, "cache miss", "key", key) // Buffered with "info" level.
+//	logger.Error(ctx, "query failed", err)      // Writes "cache miss", then "query failed".
+func ContextWithBuffer(ctx context.Context, size int) (context.Context, func()) {
+	b := &tailBuffer{size: max(size, 1)}
+	return context.WithValue(ctx, tailBufferKey{}, b), b.end
+}
+
+// FlushBuffer writes the records buffered for ctx, see ContextWithBuffer. Records logged after the flush
+// are written immediately. FlushBuffer does nothing if ctx has no buffer.
+func FlushBuffer(ctx context.Context) {
+	tailBufferFromContext(ctx).flush()
+}
+
+// tailBufferFromContext returns the buffer of ctx, nil if there is none.
+func tailBufferFromContext(ctx context.Context) *tailBuffer {
+	b, _ := ctx.Value(tailBufferKey{}).(*tailBuffer)
+	return b
+}
+
+// bufferedRecord is a record waiting in the buffer along with the handler to write it.
+type bufferedRecord struct {
+	ctx     context.Context
+	handler slog.Handler
+	record  slog.Record
+}
+
+// tailBuffer is a bounded buffer of records of a single request. Nil buffer accepts no records.
+type tailBuffer struct {
+	mu      sync.Mutex
+	size    int
+	records []bufferedRecord
+	flushed bool // Records are written immediately.
+	ended   bool // The request has ended, records are not accepted.
+}
+
+// accepts reports whether the record disabled by the logger level must be passed to the buffer.
+func (b *tailBuffer) accepts(level slog.Level) bool {
+	if b == nil || level >= LevelInfo {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.ended
+}
+
+// add buffers the record, or writes it if the buffer is flushed.
+func (b *tailBuffer) add(ctx context.Context, h slog.Handler, r slog.Record) {
+	b.mu.Lock()
+	if b.ended {
+		b.mu.Unlock()
+		return
+	}
+	if !b.flushed {
+		if len(b.records) == b.size {
+			b.records[0] = bufferedRecord{}
+			b.records = b.records[1:]
+		}
+		b.records = append(b.records, bufferedRecord{ctx, h, r})
+		b.mu.Unlock()
+		return
+	}
+	b.mu.Unlock()
+
+	// Errors are ignored, as slog.Logger does.
+	_ = h.Handle(ctx, r)
+}
+
+// flush writes the buffered records and switches the buffer to writing records immediately.
+func (b *tailBuffer) flush() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	records := b.records
+	b.records = nil
+	b.flushed = !b.ended
+	b.mu.Unlock()
+
+	for _, item := range records {
+		// Errors are ignored, as slog.Logger does.
+		_ = item.handler.Handle(item.ctx, item.record)
+	}
+}
+
+// end discards the buffered records and stops accepting new ones.
+func (b *tailBuffer) end() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.records = nil
+	b.ended = true
+}
@@ -0,0 +1,98 @@
+package logkit_test
+
+import (
+	"context"
+
+	logger "github.com/Averlex/logkit"
+)
+
+func (s *LoggerTestSuite) TestTailBuffer() {
+	messages := func() []string {
+		res := make([]string, 0, len(s.writer.arr))
+		for _, data := range s.writer.arr {
+			entry, err := decodeJSON(data)
+			s.Require().NoError(err, "failed to unmarshal log entry")
+			res = append(res, entry.Msg)
+		}
+		return res
+	}
+	newLogger := func() *logger.Logger {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(logger.WithConfig(map[string]any{"level": "info"}), logger.WithWriter(s.writer))
+		s.Require().NoError(err, "got error, expected nil")
+		return l
+	}
+
+	s.Run("flush on error", func() {
+		l := newLogger()
+		ctx, end := logger.ContextWithBuffer(context.Background(), 10)
+
+		l.Debug(ctx, "debug")
+		l.Named("db").Trace(logger.ContextWith(ctx, "query", "select"), "trace")
+		l.Info(ctx, "info")
+		s.Require().Equal([]string{"info"}, messages(), "records below the level must be buffered")
+
+		l.Error(ctx, "error")
+		l.Verbose(ctx, "after error")
+		s.Require().Equal([]string{"info", "debug", "trace", "error", "after error"}, messages(),
+			"buffered records must be written before the error")
+		s.Require().Contains(string(s.writer.arr[2]), `"logger":"db","query":"select"`,
+			"buffered records must keep their attributes")
+
+		end()
+		l.Debug(ctx, "after end")
+		l.Error(ctx, "error after end")
+		s.Require().Equal([]string{"info", "debug", "trace", "error", "after error", "error after end"}, messages(),
+			"records must not be buffered after end")
+	})
+
+	s.Run("healthy request", func() {
+		l := newLogger()
+		ctx, end := logger.ContextWithBuffer(context.Background(), 10)
+
+		l.Debug(ctx, "debug")
+		logger.Debug(logger.NewContext(ctx, l), "package-level debug")
+		l.Info(ctx, "info")
+		end()
+		logger.FlushBuffer(ctx)
+		s.Require().Equal([]string{"info"}, messages(), "buffered records must be discarded")
+	})
+
+	s.Run("explicit flush", func() {
+		l := newLogger()
+		ctx, end := logger.ContextWithBuffer(context.Background(), 2)
+		defer end()
+
+		l.Debug(ctx, "first")
+		l.Debug(ctx, "second")
+		l.Debug(ctx, "third")
+		logger.FlushBuffer(ctx)
+		s.Require().Equal([]string{"second", "third"}, messages(), "oldest records must be dropped")
+	})
+
+	s.Run("no stack trace", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(
+			logger.WithConfig(map[string]any{"level": "info"}),
+			logger.WithWriter(s.writer),
+			logger.WithStacktrace(logger.LevelDebug),
+		)
+		s.Require().NoError(err, "got error, expected nil")
+		ctx, end := logger.ContextWithBuffer(context.Background(), 10)
+		defer end()
+
+		l.Debug(ctx, "debug")
+		l.Error(ctx, "error")
+		s.Require().Equal([]string{"debug", "error"}, messages(), "buffered records must be written")
+		s.Require().NotContains(string(s.writer.arr[0]), `"stack"`, "stack must not be captured for buffered records")
+		s.Require().Contains(string(s.writer.arr[1]), `"stack"`, "stack must be captured for enabled records")
+	})
+
+	s.Run("no buffer", func() {
+		l := newLogger()
+		l.Debug(context.Background(), "debug")
+		logger.FlushBuffer(context.Background())
+		l.Error(context.Background(), "error")
+		s.Require().Equal([]string{"error"}, messages(), "records must not be buffered")
+	})
+}
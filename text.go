@@ -0,0 +1,76 @@
+package logkit
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// textHandler wraps slog.TextHandler to write stack traces as indented blocks below the record line
+// instead of escaped single-line values.
+type textHandler struct {
+	next slog.Handler
+	mu   *sync.Mutex // Keeps the record line and its blocks together.
+	w    io.Writer
+}
+
+// newTextHandler returns a new text handler writing to w.
+func newTextHandler(w io.Writer, opts *slog.HandlerOptions) *textHandler {
+	return &textHandler{next: slog.NewTextHandler(w, opts), mu: &sync.Mutex{}, w: w}
+}
+
+// Enabled implements slog.Handler.
+func (h *textHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *textHandler) Handle(ctx context.Context, r slog.Record) error {
+	var stacks []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		if _, ok := a.Value.Any().(Stack); ok {
+			stacks = append(stacks, a)
+		}
+		return true
+	})
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(stacks) == 0 {
+		return h.next.Handle(ctx, r)
+	}
+
+	line := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if _, ok := a.Value.Any().(Stack); !ok {
+			line.AddAttrs(a)
+		}
+		return true
+	})
+	if err := h.next.Handle(ctx, line); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	for _, a := range stacks {
+		b.WriteString(consoleIndent + a.Key + ":\n")
+		for _, l := range strings.Split(a.Value.Any().(Stack).String(), "\n") {
+			b.WriteString(consoleIndent + consoleIndent + l + "\n")
+		}
+	}
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+// WithAttrs implements slog.Handler.
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &textHandler{h.next.WithAttrs(attrs), h.mu, h.w}
+}
+
+// WithGroup implements slog.Handler.
+func (h *textHandler) WithGroup(name string) slog.Handler {
+	return &textHandler{h.next.WithGroup(name), h.mu, h.w}
+}
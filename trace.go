@@ -0,0 +1,59 @@
+package logkit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceKeys holds the attribute keys of the trace context.
+type traceKeys struct {
+	traceID    string
+	spanID     string
+	traceFlags string
+}
+
+// WithTraceContext enables adding the OpenTelemetry trace context to records. If the context passed
+// to a logging method carries a valid span context, the following attributes are added in W3C format:
+//   - trace_id: 32 hex characters, see TraceIDKey.
+//   - span_id: 16 hex characters, see SpanIDKey.
+//   - trace_flags: 2 hex characters, e.g. "01" for sampled spans, see TraceFlagsKey.
+//
+// The span context is only read from ctx, so no OpenTelemetry SDK or collector is required.
+// Use WithTraceContextKeys to change the attribute keys.
+func WithTraceContext() Option {
+	return WithTraceContextKeys(TraceIDKey, SpanIDKey, TraceFlagsKey)
+}
+
+// WithTraceContextKeys is the same as WithTraceContext, but allows to set custom attribute keys,
+// e.g. "trace.id", "span.id" and "trace.flags". Empty key disables the corresponding attribute.
+func WithTraceContextKeys(traceID, spanID, traceFlags string) Option {
+	return func(c *Config) error {
+		if traceID == "" && spanID == "" && traceFlags == "" {
+			return errors.New("at least one trace context key must be set")
+		}
+		c.traceKeys = &traceKeys{traceID: traceID, spanID: spanID, traceFlags: traceFlags}
+		return nil
+	}
+}
+
+// appendTraceContext appends trace context attributes of the span in ctx to args, if the span context is valid.
+func (k *traceKeys) appendTraceContext(ctx context.Context, args []any) []any {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return args
+	}
+
+	if k.traceID != "" {
+		args = append(args, slog.String(k.traceID, sc.TraceID().String()))
+	}
+	if k.spanID != "" {
+		args = append(args, slog.String(k.spanID, sc.SpanID().String()))
+	}
+	if k.traceFlags != "" {
+		args = append(args, slog.String(k.traceFlags, sc.TraceFlags().String()))
+	}
+	return args
+}
@@ -0,0 +1,92 @@
+package logkit_test
+
+import (
+	"context"
+	"encoding/json"
+
+	logger "github.com/Averlex/logkit"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanContext returns a context carrying a sampled remote span context.
+func spanContext() context.Context {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func (s *LoggerTestSuite) TestTraceContext() {
+	testCases := []struct {
+		name     string
+		opt      logger.Option
+		ctx      context.Context
+		expected map[string]any
+	}{
+		{
+			name: "default keys",
+			opt:  logger.WithTraceContext(),
+			ctx:  spanContext(),
+			expected: map[string]any{
+				"trace_id":    "4bf92f3577b34da6a3ce929d0e0e4736",
+				"span_id":     "00f067aa0ba902b7",
+				"trace_flags": "01",
+			},
+		},
+		{
+			name: "custom keys",
+			opt:  logger.WithTraceContextKeys("trace.id", "span.id", ""),
+			ctx:  spanContext(),
+			expected: map[string]any{
+				"trace.id": "4bf92f3577b34da6a3ce929d0e0e4736",
+				"span.id":  "00f067aa0ba902b7",
+			},
+		},
+		{
+			name:     "no span",
+			opt:      logger.WithTraceContext(),
+			ctx:      context.Background(),
+			expected: map[string]any{},
+		},
+	}
+
+	for _, tC := range testCases {
+		s.Run(tC.name, func() {
+			s.writer.CleanUp()
+			l, err := logger.NewLogger(
+				logger.WithConfig(map[string]any{"level": "info"}),
+				logger.WithWriter(s.writer),
+				tC.opt,
+			)
+			s.Require().NoError(err, "got error, expected nil")
+
+			l.Info(tC.ctx, "traced")
+			s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+
+			var entry map[string]any
+			s.Require().NoError(json.Unmarshal(s.writer.arr[0], &entry), "failed to unmarshal log entry")
+			delete(entry, "time")
+			delete(entry, "level")
+			delete(entry, "msg")
+			s.Require().Equal(tC.expected, entry, "unexpected trace attributes")
+		})
+	}
+
+	s.Run("disabled", func() {
+		s.writer.CleanUp()
+		l, err := logger.NewLogger(logger.WithConfig(map[string]any{"level": "info"}), logger.WithWriter(s.writer))
+		s.Require().NoError(err, "got error, expected nil")
+
+		l.Info(spanContext(), "not traced")
+		s.Require().Len(s.writer.arr, 1, "unexpected amount of logs received")
+		s.Require().NotContains(string(s.writer.arr[0]), "trace_id", "unexpected trace attributes")
+	})
+
+	s.Run("invalid keys", func() {
+		_, err := logger.NewLogger(logger.WithTraceContextKeys("", "", ""))
+		s.Require().Error(err, "got nil, expected error")
+	})
+}
@@ -1,18 +1,26 @@
 package logkit
 
 import (
+	"io"
 	"log/slog"
+	"math"
+	"reflect"
 	"strings"
 	"time"
 )
 
 // buildHandler returns a handler based on config.
+// The handler accepts records of any level: levels are checked by Logger, as they may be changed at runtime
+// and differ between named loggers.
 func buildHandler(c *Config) slog.Handler {
+	timeTemplate, sourcePath := c.timeTemplate, c.sourcePath
 	c.handlerOpts = &slog.HandlerOptions{
-		Level: c.level,
+		Level:     slog.Level(math.MinInt),
+		AddSource: c.addSource,
 		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 			attr := replaceLevelAttr(groups, a)
-			return replaceTimeAttrs(groups, attr, c.timeTemplate)
+			attr = replaceSourceAttr(groups, attr, sourcePath)
+			return replaceTimeAttrs(groups, attr, timeTemplate)
 		},
 	}
 
@@ -20,7 +28,11 @@ func buildHandler(c *Config) slog.Handler {
 	case "json", "":
 		return slog.NewJSONHandler(c.writer, c.handlerOpts)
 	case "text":
-		return slog.NewTextHandler(c.writer, c.handlerOpts)
+		return newTextHandler(c.writer, c.handlerOpts)
+	case "logfmt":
+		return newLogfmtHandler(c.writer, c.handlerOpts)
+	case "console":
+		return newConsoleHandler(c.writer, c.handlerOpts)
 	default:
 		return slog.NewJSONHandler(c.writer, c.handlerOpts)
 	}
@@ -75,3 +87,65 @@ func replaceLevelAttr(groups []string, a slog.Attr) slog.Attr {
 
 	return a
 }
+
+// levelName returns a name of the given log level. Unknown levels are formatted by slog.
+func levelName(level slog.Level) string {
+	if name, ok := levelNames[level]; ok {
+		return name
+	}
+	return level.String()
+}
+
+// setWriter sets the writer for the logger. owned is closed on Logger.Close and must be nil
+// for writers provided by the user. Previously owned writer is closed, as it is no longer used.
+func (c *Config) setWriter(w io.Writer, owned io.Closer) {
+	if c.ownedWriter != nil {
+		_ = c.ownedWriter.Close()
+	}
+	c.writer = w
+	c.ownedWriter = owned
+}
+
+// fileOptions returns log file options from the validated config.
+func fileOptions(cfg map[string]any) FileOptions {
+	opts := FileOptions{}
+	if v, ok := cfg["file_path"]; ok {
+		opts.Path = v.(string)
+	}
+	if v, ok := cfg["max_size_mb"]; ok {
+		opts.MaxSizeMB, _ = toInt(v)
+	}
+	if v, ok := cfg["max_age"]; ok {
+		opts.MaxAge, _ = time.ParseDuration(v.(string))
+	}
+	if v, ok := cfg["max_backups"]; ok {
+		opts.MaxBackups, _ = toInt(v)
+	}
+	if v, ok := cfg["rotation"]; ok {
+		opts.Rotation = strings.ToLower(v.(string))
+	}
+	if v, ok := cfg["compress"]; ok {
+		opts.Compress = v.(bool)
+	}
+	return opts
+}
+
+// toInt converts a numeric config value to int.
+// Floats are accepted only if they have no fractional part, as JSON numbers are decoded to float64.
+func toInt(v any) (int, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) {
+			return 0, false
+		}
+		return int(f), true
+	default:
+		return 0, false
+	}
+}
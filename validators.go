@@ -2,34 +2,111 @@ package logkit
 
 import (
 	"fmt"
+	"log/slog"
 	"reflect"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 )
 
+// allowedLevels returns names of the supported log levels in ascending order.
+func allowedLevels() []string {
+	levels := make([]slog.Level, 0, len(levelNames))
+	for level := range levelNames {
+		levels = append(levels, level)
+	}
+	slices.Sort(levels)
+
+	res := make([]string, len(levels))
+	for i, level := range levels {
+		res[i] = strings.ToLower(levelNames[level])
+	}
+	return res
+}
+
 // validateLogLevel is a helper that checks if log level is valid.
-func validateLogLevel(cfg map[string]any, ve *validationError) {
+func validateLogLevel(cfg map[string]any, ve *ConfigError) {
 	if val, ok := cfg["level"]; ok {
 		levelStr, ok := val.(string)
 		if !ok {
-			ve.invalidTypes = append(ve.invalidTypes, "level")
+			ve.addWrongType("level", val)
 			return
 		}
 		levelStr = strings.ToLower(levelStr)
 
 		if _, ok := levelValues[levelStr]; !ok {
-			ve.invalidValues = append(ve.invalidValues, "level")
+			ve.addInvalidValue("level", val, ErrInvalidLevel, allowedLevels()...)
+			return
+		}
+	}
+}
+
+// validateStacktraceLevel is a helper that checks if stack trace level is valid. Empty level is allowed.
+func validateStacktraceLevel(cfg map[string]any, ve *ConfigError) {
+	if val, ok := cfg["stacktrace_level"]; ok {
+		levelStr, ok := val.(string)
+		if !ok {
+			ve.addWrongType("stacktrace_level", val)
+			return
+		}
+
+		if _, ok := levelValues[strings.ToLower(levelStr)]; !ok && levelStr != "" {
+			ve.addInvalidValue("stacktrace_level", val, ErrInvalidLevel, allowedLevels()...)
+		}
+	}
+}
+
+// validateDedupOptions is a helper that checks if deduplication options are valid.
+func validateDedupOptions(cfg map[string]any, ve *ConfigError) {
+	if val, ok := cfg["dedup_window"]; ok {
+		windowStr, ok := val.(string)
+		if !ok {
+			ve.addWrongType("dedup_window", val)
+		} else if windowStr != "" {
+			if window, err := time.ParseDuration(windowStr); err != nil || window < 0 {
+				ve.addInvalidValue("dedup_window", val, ErrInvalidDedupOptions)
+			}
+		}
+	}
+
+	if val, ok := cfg["dedup_attrs"]; ok {
+		if _, ok := val.(string); !ok {
+			ve.addWrongType("dedup_attrs", val)
+		}
+	}
+}
+
+// validateComponentLevels is a helper that checks if component levels are valid.
+// Invalid entries are reported as "levels.<component>".
+func validateComponentLevels(cfg map[string]any, ve *ConfigError) {
+	if val, ok := cfg["levels"]; ok {
+		levels, ok := toStringMap(val)
+		if !ok {
+			ve.addWrongType("levels", val)
 			return
 		}
+
+		names := make([]string, 0, len(levels))
+		for name := range levels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if _, ok := levelValues[strings.ToLower(levels[name])]; !ok || name == "" {
+				ve.addInvalidValue("levels."+name, levels[name], ErrInvalidLevel, allowedLevels()...)
+			}
+		}
 	}
 }
 
 // validateTimeFormat is a helper that checks if time format is valid.
-func validateTimeFormat(cfg map[string]any, ve *validationError) {
+func validateTimeFormat(cfg map[string]any, ve *ConfigError) {
 	if val, ok := cfg["time_template"]; ok {
 		timeTmpl, ok := val.(string)
 		if !ok {
-			ve.invalidTypes = append(ve.invalidTypes, "time_template")
+			ve.addWrongType("time_template", val)
 			return
 		}
 
@@ -41,49 +118,118 @@ func validateTimeFormat(cfg map[string]any, ve *validationError) {
 		formatted := testTime.Format(timeTmpl)
 		parsedTime, err := time.Parse(timeTmpl, formatted)
 		if err != nil || !parsedTime.Equal(testTime) {
-			ve.invalidValues = append(ve.invalidValues, "time_template")
+			ve.addInvalidValue("time_template", val, ErrInvalidTimeTemplate)
 		}
 	}
 }
 
 // validateWriter is a helper that checks if writer is valid.
-func validateWriter(cfg map[string]any, ve *validationError) {
+func validateWriter(cfg map[string]any, ve *ConfigError) {
 	if val, ok := cfg["log_stream"]; ok {
 		writerStr, ok := val.(string)
 		if !ok {
-			ve.invalidTypes = append(ve.invalidTypes, "log_stream")
+			ve.addWrongType("log_stream", val)
 			return
 		}
 
 		switch writerStr {
 		case "stdout", "stderr", "":
+		case "file":
+			if path, ok := cfg["file_path"].(string); !ok || path == "" {
+				ve.addInvalidValue("file_path", cfg["file_path"], ErrInvalidFileOptions)
+			}
 		default:
-			ve.invalidValues = append(ve.invalidValues, "log_stream")
+			ve.addInvalidValue("log_stream", val, ErrInvalidLogStream, "stdout", "stderr", "file")
+		}
+	}
+}
+
+// validateFileOptions is a helper that checks if log file options are valid.
+func validateFileOptions(cfg map[string]any, ve *ConfigError) {
+	for _, field := range []string{"max_size_mb", "max_backups"} {
+		if val, ok := cfg[field]; ok {
+			n, ok := toInt(val)
+			if !ok {
+				ve.addWrongType(field, val)
+				continue
+			}
+			if n < 0 {
+				ve.addInvalidValue(field, val, ErrInvalidFileOptions)
+			}
+		}
+	}
+
+	if val, ok := cfg["max_age"]; ok {
+		ageStr, ok := val.(string)
+		if !ok {
+			ve.addWrongType("max_age", val)
+		} else if ageStr != "" {
+			if age, err := time.ParseDuration(ageStr); err != nil || age < 0 {
+				ve.addInvalidValue("max_age", val, ErrInvalidFileOptions)
+			}
+		}
+	}
+
+	if val, ok := cfg["rotation"]; ok {
+		rotationStr, ok := val.(string)
+		if !ok {
+			ve.addWrongType("rotation", val)
+			return
+		}
+
+		switch strings.ToLower(rotationStr) {
+		case RotationNone, RotationHourly, RotationDaily:
+		default:
+			ve.addInvalidValue("rotation", val, ErrInvalidFileOptions, RotationHourly, RotationDaily)
 		}
 	}
 }
 
 // validateLogType is a helper that checks if log type is valid.
-func validateLogType(cfg map[string]any, ve *validationError) {
+func validateLogType(cfg map[string]any, ve *ConfigError) {
 	if val, ok := cfg["format"]; ok {
 		logTypeStr, ok := val.(string)
 		if !ok {
-			ve.invalidTypes = append(ve.invalidTypes, "format")
+			ve.addWrongType("format", val)
 			return
 		}
 
 		switch logTypeStr {
-		case "json", "text", "":
+		case "json", "text", "logfmt", "console", "":
 		default:
-			ve.invalidValues = append(ve.invalidValues, "format")
+			ve.addInvalidValue("format", val, ErrInvalidFormat, "json", "text", "logfmt", "console")
 		}
 	}
 }
 
-// validateTypes returns missing and wrong type fields found in args.
+// validateSourcePath is a helper that checks if source path form is valid.
+func validateSourcePath(cfg map[string]any, ve *ConfigError) {
+	if val, ok := cfg["source_path"]; ok {
+		sourcePathStr, ok := val.(string)
+		if !ok {
+			ve.addWrongType("source_path", val)
+			return
+		}
+
+		switch strings.ToLower(sourcePathStr) {
+		case SourcePathFull, SourcePathModule, SourcePathFile, "":
+		default:
+			ve.addInvalidValue("source_path", val, ErrInvalidSourcePath, SourcePathFull, SourcePathModule, SourcePathFile)
+		}
+	}
+}
+
+// validateTypes reports wrong type fields found in args.
 // optionalFields is a map of field names with their expected types.
-func validateTypes(args map[string]any, optionalFields map[string]any) (invalidTypes []string) {
-	for field, expectedVal := range optionalFields {
+func validateTypes(args map[string]any, optionalFields map[string]any, ve *ConfigError) {
+	fields := make([]string, 0, len(optionalFields))
+	for field := range optionalFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		expectedVal := optionalFields[field]
 		val, exists := args[field]
 		if !exists {
 			continue
@@ -91,14 +237,21 @@ func validateTypes(args map[string]any, optionalFields map[string]any) (invalidT
 
 		// Default type switch will end up with false positive results. E.g., 123.(string) -> ok.
 		// Using soft type check for string types, as, i.e., timeFormat in time package is untyped string.
+		// Numeric fields accept any numeric kind, as, i.e., JSON numbers are decoded as float64.
 		expectedKind := reflect.TypeOf(expectedVal).Kind()
 		actualKind := reflect.TypeOf(val).Kind()
+		if isNumericKind(expectedKind) && isNumericKind(actualKind) {
+			continue
+		}
 		if expectedKind != actualKind {
-			invalidTypes = append(invalidTypes, field)
+			ve.addWrongType(field, val)
 		}
 	}
+}
 
-	return invalidTypes
+// isNumericKind returns true if the kind is an integer or a float one.
+func isNumericKind(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Uint64) || k == reflect.Float32 || k == reflect.Float64
 }
 
 // checkDefaults sets default values for the logger configurations, if they are empty.
@@ -108,6 +261,7 @@ func (c *Config) checkDefaults() {
 	}
 	if c.writer == nil {
 		c.writer = DefaultWriterValue
+		c.logStream = DefaultWriter
 	}
 	if c.timeTemplate == "" {
 		c.timeTemplate = DefaultTimeTemplate
@@ -115,6 +269,9 @@ func (c *Config) checkDefaults() {
 	if c.setupLevel {
 		c.level = DefaultLevelValue
 	}
+	if c.sourcePath == "" {
+		c.sourcePath = SourcePathFull
+	}
 }
 
 // validateLoggableContextKeys checks if the provided key types are compatible with slog key requirements.
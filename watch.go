@@ -0,0 +1,263 @@
+package logkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// watchConfig holds the settings of the config file watching.
+type watchConfig struct {
+	path     string
+	interval time.Duration
+	stat     fileStamp // State of the file when it was applied.
+}
+
+// fileStamp identifies the state of a file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// statFile returns the current state of the file.
+func statFile(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{info.ModTime(), info.Size()}, nil
+}
+
+// WithConfigWatch applies configuration from the file, the same way as WithConfigFile does in non-strict mode,
+// and keeps watching it: the file is checked for changes every interval and reapplied to the running logger.
+//
+// On reload the following settings are swapped atomically for the logger and all loggers derived from it:
+// level and component levels, format, time template and output (log stream and file options).
+// Levels are changed only if they are present in the file, so levels set via SetLevel survive unrelated changes.
+// Other settings, e.g. source and stack trace ones, are applied only on the logger initialization.
+//
+// The file is validated before applying. If it is invalid, the previous configuration is kept
+// and the error is logged with "config reload failed" message. Watching stops on Logger.Close.
+func WithConfigWatch(path string, interval time.Duration) Option {
+	return func(c *Config) error {
+		if interval <= 0 {
+			return errors.New("config watch interval must be positive")
+		}
+
+		stat, err := statFile(path)
+		if err != nil {
+			return fmt.Errorf("config file reading failed: %w", err)
+		}
+		if err := WithConfigFile(path, false)(c); err != nil {
+			return err
+		}
+
+		c.watch = &watchConfig{path: path, interval: interval, stat: stat}
+		return nil
+	}
+}
+
+// swapState holds the base handler which might be replaced at runtime.
+type swapState struct {
+	mu   sync.RWMutex // Held for reading while records are handled, so replaced outputs are closed safely.
+	base slog.Handler
+	gen  uint64 // Incremented on each swap.
+}
+
+// swap replaces the base handler.
+func (s *swapState) swap(h slog.Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.base = h
+	s.gen++
+}
+
+// swapHandler is a slog.Handler which delegates to the current base handler of the shared state.
+// Attributes and groups added via WithAttrs and WithGroup are reapplied to the new base handler after swaps.
+type swapHandler struct {
+	state  *swapState
+	derive []func(slog.Handler) slog.Handler // WithAttrs and WithGroup calls, in order.
+	cache  *atomic.Pointer[derivedHandler]
+}
+
+// derivedHandler is the base handler of the given generation with derive functions applied.
+type derivedHandler struct {
+	gen uint64
+	h   slog.Handler
+}
+
+// newSwapHandler returns a new swap handler with the given base handler.
+func newSwapHandler(base slog.Handler) *swapHandler {
+	return &swapHandler{state: &swapState{base: base}, cache: &atomic.Pointer[derivedHandler]{}}
+}
+
+// current returns the derived handler for the current base handler. The state must be locked for reading.
+func (h *swapHandler) current() slog.Handler {
+	if d := h.cache.Load(); d != nil && d.gen == h.state.gen {
+		return d.h
+	}
+
+	res := h.state.base
+	for _, f := range h.derive {
+		res = f(res)
+	}
+	h.cache.Store(&derivedHandler{h.state.gen, res})
+	return res
+}
+
+// Enabled implements slog.Handler.
+func (h *swapHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	h.state.mu.RLock()
+	defer h.state.mu.RUnlock()
+	return h.current().Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *swapHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.state.mu.RLock()
+	defer h.state.mu.RUnlock()
+	return h.current().Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *swapHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.with(func(base slog.Handler) slog.Handler { return base.WithAttrs(attrs) })
+}
+
+// WithGroup implements slog.Handler.
+func (h *swapHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(func(base slog.Handler) slog.Handler { return base.WithGroup(name) })
+}
+
+// with returns a copy of the handler with the derive function added.
+func (h *swapHandler) with(f func(slog.Handler) slog.Handler) *swapHandler {
+	return &swapHandler{
+		state:  h.state,
+		derive: append(slices.Clip(h.derive), f),
+		cache:  &atomic.Pointer[derivedHandler]{},
+	}
+}
+
+// configWatcher periodically reapplies the config file to the logger.
+// It owns the output of the logger, closing it on Close or when it is replaced.
+type configWatcher struct {
+	logg     *Logger
+	swap     *swapState
+	watch    watchConfig
+	mu       sync.Mutex // Protects cfg.
+	cfg      Config     // The applied configuration.
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// newConfigWatcher returns a new watcher of the config applied to the logger. Call run to start watching.
+func newConfigWatcher(logg *Logger, swap *swapState, cfg *Config) *configWatcher {
+	return &configWatcher{
+		logg:  logg,
+		swap:  swap,
+		watch: *cfg.watch,
+		cfg:   *cfg,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// run checks the config file every interval until the watcher is closed.
+func (w *configWatcher) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.watch.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+
+		stat, err := statFile(w.watch.path)
+		if err != nil || stat == w.watch.stat {
+			// A missing file is a usual state while the file is being replaced, it is checked again on the next tick.
+			continue
+		}
+		w.watch.stat = stat
+
+		if err := w.reload(); err != nil {
+			w.logg.Error(context.Background(), "config reload failed", "path", w.watch.path, "error", err)
+			continue
+		}
+		w.logg.Info(context.Background(), "config reloaded", "path", w.watch.path)
+	}
+}
+
+// reload validates the config file and applies it to the logger.
+func (w *configWatcher) reload() error {
+	cfg, positions, err := readConfigFile(w.watch.path)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// The current output must not be closed until the new configuration is applied.
+	next := w.cfg
+	next.ownedWriter = nil
+	next.componentLevels = nil
+	if err := next.applyConfig(cfg, &ConfigError{positions: positions}); err != nil {
+		return err
+	}
+
+	var replaced io.Closer
+	if _, ok := cfg["log_stream"]; ok {
+		replaced = w.cfg.ownedWriter
+	} else {
+		next.ownedWriter = w.cfg.ownedWriter
+	}
+
+	w.swap.swap(next.handler)
+	if _, ok := cfg["level"]; ok {
+		w.logg.levels.setLevel("", next.level)
+	}
+	for name, level := range next.componentLevels {
+		w.logg.levels.setLevel(name, level)
+	}
+	w.cfg = next
+	w.logg.settings.Store(settingsFromConfig(&next))
+
+	if replaced != nil {
+		return replaced.Close()
+	}
+	return nil
+}
+
+// Close stops watching and closes the output owned by the logger, if any.
+func (w *configWatcher) Close() error {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cfg.ownedWriter == nil {
+		return nil
+	}
+	err := w.cfg.ownedWriter.Close()
+	w.cfg.ownedWriter = nil
+	return err
+}
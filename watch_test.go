@@ -0,0 +1,143 @@
+package logkit_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	logger "github.com/Averlex/logkit"
+)
+
+// syncWriter is a log entries collector safe for concurrent use.
+type syncWriter struct {
+	mu sync.Mutex
+	w  *customWriter
+}
+
+func newSyncWriter() *syncWriter {
+	return &syncWriter{w: newCustomWriter()}
+}
+
+func (w *syncWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(data)
+}
+
+// lines returns a snapshot of the written entries.
+func (w *syncWriter) lines() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	res := make([]string, 0, len(w.w.arr))
+	for _, data := range w.w.arr {
+		res = append(res, string(data))
+	}
+	return res
+}
+
+// contains returns true if any of the written entries contains substr.
+func (w *syncWriter) contains(substr string) bool {
+	for _, l := range w.lines() {
+		if strings.Contains(l, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *LoggerTestSuite) TestConfigWatch() {
+	const interval = 5 * time.Millisecond
+	ctx := context.Background()
+
+	write := func(path, content string) {
+		s.Require().NoError(os.WriteFile(path, []byte(content), 0o600), "failed to write config file")
+	}
+
+	s.Run("reload", func() {
+		w := newSyncWriter()
+		path := filepath.Join(s.T().TempDir(), "logkit.json")
+		write(path, `{"level": "info", "format": "json"}`)
+
+		l, err := logger.NewLogger(logger.WithConfigWatch(path, interval), logger.WithWriter(w))
+		s.Require().NoError(err, "got error, expected nil")
+		defer func() { _ = l.Close(ctx) }()
+
+		derived := l.With("component", "derived")
+		derived.Info(ctx, "before")
+		s.Require().True(w.contains(`"msg":"before","component":"derived"`), "unexpected log entry")
+
+		write(path, `{"level": "debug", "format": "logfmt", "levels": {"db": "trace"}}`)
+		s.Require().Eventually(func() bool { return l.Level() == "DEBUG" }, time.Second, interval, "config was not reloaded")
+
+		derived.Debug(ctx, "after")
+		l.Named("db").Trace(ctx, "db trace")
+		s.Require().True(w.contains("level=DEBUG msg=after component=derived"), "format was not swapped")
+		s.Require().True(w.contains("msg=\"db trace\""), "component levels were not applied")
+
+		write(path, `{"level": "loud", "format": "text"}`)
+		s.Require().Eventually(func() bool { return w.contains("config reload failed") }, time.Second, interval,
+			"reload error was not logged")
+		s.Require().True(w.contains("invalid_value=level ("+path+":1)"), "unexpected diagnostic")
+		s.Require().Equal("DEBUG", l.Level(), "invalid config was applied")
+
+		l.Info(ctx, "kept")
+		s.Require().True(w.contains("level=INFO msg=kept"), "previous format was not kept")
+	})
+
+	s.Run("output", func() {
+		dir := s.T().TempDir()
+		path := filepath.Join(dir, "logkit.yaml")
+		first, second := filepath.Join(dir, "first.log"), filepath.Join(dir, "second.log")
+		write(path, "level: info\nlog_stream: file\nfile_path: "+first+"\n")
+
+		l, err := logger.NewLogger(logger.WithConfigWatch(path, interval))
+		s.Require().NoError(err, "got error, expected nil")
+		l.Info(ctx, "first")
+
+		write(path, "level: info\nlog_stream: file\nfile_path: "+second+"\ntime_template: '"+time.DateTime+"'\n")
+		s.Require().Eventually(func() bool {
+			data, _ := os.ReadFile(second)
+			return strings.Contains(string(data), "config reloaded")
+		}, time.Second, interval, "output was not swapped")
+		l.Info(ctx, "second")
+		s.Require().NoError(l.Close(ctx), "got error, expected nil")
+
+		data, err := os.ReadFile(first)
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().Contains(string(data), `"msg":"first"`, "unexpected first file content")
+		s.Require().NotContains(string(data), `"msg":"second"`, "unexpected first file content")
+
+		data, err = os.ReadFile(second)
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().Contains(string(data), `"msg":"second"`, "unexpected second file content")
+	})
+
+	s.Run("stopped on close", func() {
+		w := newSyncWriter()
+		path := filepath.Join(s.T().TempDir(), "logkit.json")
+		write(path, `{"level": "info"}`)
+
+		l, err := logger.NewLogger(logger.WithConfigWatch(path, interval), logger.WithWriter(w))
+		s.Require().NoError(err, "got error, expected nil")
+		s.Require().NoError(l.Close(ctx), "got error, expected nil")
+
+		write(path, `{"level": "debug"}`)
+		time.Sleep(10 * interval)
+		s.Require().Equal("INFO", l.Level(), "config was reloaded after close")
+	})
+
+	s.Run("invalid options", func() {
+		path := filepath.Join(s.T().TempDir(), "logkit.json")
+		_, err := logger.NewLogger(logger.WithConfigWatch(path, interval))
+		s.Require().ErrorIs(err, os.ErrNotExist, "unexpected error")
+
+		write(path, `{"level": "loud"}`)
+		_, err = logger.NewLogger(logger.WithConfigWatch(path, interval))
+		s.Require().Error(err, "got nil, expected error")
+		_, err = logger.NewLogger(logger.WithConfigWatch(path, 0))
+		s.Require().Error(err, "got nil, expected error")
+	})
+}